@@ -12,14 +12,14 @@ type vResult struct {
 type SearchResult map[Vertex]*vResult
 
 func bfs(v Vertex, g Graph, res SearchResult, tick int) int {
-	Q := queue.New()
+	Q := queue.NewRing[*vResult](0)
 
 	res[v] = &vResult{start: tick, v: v}
 	Q.Enqueue(res[v])
 	tick++
 
 	for Q.Count() > 0 {
-		s := Q.Dequeue().(*vResult)
+		s, _ := Q.Dequeue()
 		s.end = tick
 		tick++
 		for e := range g.Neighbors(s.v) {
@@ -0,0 +1,177 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when using a queue which has been closed
+var ErrClosed = errors.New("queue is closed")
+
+// Blocking is a queue which is safe for concurrent use, callers can wait
+// for values to arrive or, if it is bounded, for room to add them
+type Blocking[T any] interface {
+	// Enqueue adds val, waiting while the queue is full.  It fails if the
+	// queue is closed or ctx is done first.
+	Enqueue(ctx context.Context, val T) error
+	// TryEnqueue adds val without waiting, false if the queue is full or closed
+	TryEnqueue(val T) bool
+	// Dequeue removes the front of the queue, waiting while it is empty.  It
+	// fails once the queue is closed and drained, or if ctx is done first.
+	Dequeue(ctx context.Context) (T, error)
+	// TryDequeue removes the front of the queue without waiting, false if
+	// it is empty
+	TryDequeue() (T, bool)
+	Count() int
+	// Close stops further values being added and wakes every waiter,
+	// values already queued can still be dequeued
+	Close()
+}
+
+// signal wakes the goroutines waiting for one condition, they select on
+// its channel alongside their context.  The channel is only made once
+// someone waits, so notifying nobody costs nothing.
+type signal struct {
+	ch chan struct{}
+}
+
+// wait returns a channel which is closed by the next notify
+func (s *signal) wait() <-chan struct{} {
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	return s.ch
+}
+
+// notify wakes everyone waiting
+func (s *signal) notify() {
+	if s.ch != nil {
+		close(s.ch)
+		s.ch = nil
+	}
+}
+
+type blocking[T any] struct {
+	mu       sync.Mutex
+	items    ring[T]
+	capacity int
+	closed   bool
+	// consumers only wait while the queue is empty and producers while it
+	// is full, so each is only woken when the queue stops being so
+	notEmpty signal
+	notFull  signal
+}
+
+// wait releases mu until s is notified or ctx is done, mu must be held
+func (q *blocking[T]) wait(ctx context.Context, s *signal) error {
+	ch := s.wait()
+	q.mu.Unlock()
+	defer q.mu.Lock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *blocking[T]) full() bool {
+	return q.capacity > 0 && q.items.count >= q.capacity
+}
+
+// enqueue adds val and wakes consumers if the queue was empty, mu must be
+// held
+func (q *blocking[T]) enqueue(val T) {
+	q.items.Enqueue(val)
+	if q.items.count == 1 {
+		q.notEmpty.notify()
+	}
+}
+
+// dequeue removes the front and wakes producers if the queue was full, mu
+// must be held
+func (q *blocking[T]) dequeue() (T, bool) {
+	result, ok := q.items.Dequeue()
+	if ok && q.items.count == q.capacity-1 {
+		q.notFull.notify()
+	}
+	return result, ok
+}
+
+func (q *blocking[T]) Enqueue(ctx context.Context, val T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && q.full() {
+		if err := q.wait(ctx, &q.notFull); err != nil {
+			return err
+		}
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	q.enqueue(val)
+	return nil
+}
+
+func (q *blocking[T]) TryEnqueue(val T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.full() {
+		return false
+	}
+	q.enqueue(val)
+	return true
+}
+
+func (q *blocking[T]) Dequeue(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && q.items.count == 0 {
+		if err := q.wait(ctx, &q.notEmpty); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	result, ok := q.dequeue()
+	if !ok {
+		return result, ErrClosed
+	}
+	return result, nil
+}
+
+func (q *blocking[T]) TryDequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dequeue()
+}
+
+func (q *blocking[T]) Count() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.count
+}
+
+func (q *blocking[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.notEmpty.notify()
+		q.notFull.notify()
+	}
+}
+
+// NewBlocking constructs a new concurrent queue holding at most capacity
+// values, a capacity <= 0 leaves it unbounded
+func NewBlocking[T any](capacity int) Blocking[T] {
+	q := &blocking[T]{capacity: capacity}
+	q.items.data = make([]T, defaultRingCapacity)
+	return q
+}
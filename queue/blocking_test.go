@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingProducersConsumers(t *testing.T) {
+	const producers, perProducer = 4, 500
+	q := NewBlocking[int](8)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.Enqueue(ctx, p*perProducer+i); err != nil {
+					t.Error(err)
+				}
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	var mu sync.Mutex
+	var consumers sync.WaitGroup
+	for c := 0; c < 3; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				val, err := q.Dequeue(ctx)
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				mu.Lock()
+				seen[val] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	q.Close()
+	consumers.Wait()
+
+	for i, ok := range seen {
+		if !ok {
+			t.Fatalf("Value %d was never dequeued", i)
+		}
+	}
+}
+
+func TestBlockingCancel(t *testing.T) {
+	q := NewBlocking[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded but found %v", err)
+	}
+
+	if !q.TryEnqueue(1) {
+		t.Fatal("Expected TryEnqueue to succeed")
+	}
+	if q.TryEnqueue(2) {
+		t.Error("Expected TryEnqueue to fail on a full queue")
+	}
+	if err := q.Enqueue(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded but found %v", err)
+	}
+	if q.Count() != 1 {
+		t.Errorf("Expected 1 sized Queue but found %d", q.Count())
+	}
+}
+
+func TestBlockingEnqueueWaitsForRoom(t *testing.T) {
+	q := NewBlocking[int](1)
+	q.TryEnqueue(1)
+
+	done := make(chan error)
+	go func() {
+		done <- q.Enqueue(context.Background(), 2)
+	}()
+
+	// make sure the producer is parked before making room for it
+	b := q.(*blocking[int])
+	for {
+		b.mu.Lock()
+		parked := b.notFull.ch != nil
+		b.mu.Unlock()
+		if parked {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Expected Enqueue to wait for room but it returned %v", err)
+	default:
+	}
+
+	if val, ok := q.TryDequeue(); !ok || val != 1 {
+		t.Fatalf("Expected dequeue value of 1 but found %d", val)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if val, err := q.Dequeue(context.Background()); err != nil || val != 2 {
+		t.Errorf("Expected dequeue value of 2 but found %d, %v", val, err)
+	}
+}
+
+func TestBlockingCloseWakesWaiters(t *testing.T) {
+	q := NewBlocking[int](0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := q.Dequeue(context.Background()); !errors.Is(err, ErrClosed) {
+				t.Errorf("Expected ErrClosed but found %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+
+	if err := q.Enqueue(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed but found %v", err)
+	}
+}
+
+func TestBlockingDrainsAfterClose(t *testing.T) {
+	q := NewBlocking[int](0)
+	q.TryEnqueue(1)
+	q.Close()
+
+	if val, err := q.Dequeue(context.Background()); err != nil || val != 1 {
+		t.Errorf("Expected dequeue value of 1 but found %d, %v", val, err)
+	}
+	if _, err := q.Dequeue(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed but found %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package queue
+
+// Deque is a double-ended queue, values can be added and removed at
+// either end
+type Deque[T any] interface {
+	PushFront(T)
+	PushBack(T)
+	// PopFront removes the front of the deque, false if it is empty
+	PopFront() (T, bool)
+	// PopBack removes the back of the deque, false if it is empty
+	PopBack() (T, bool)
+	// PeekFront returns the front of the deque, false if it is empty
+	PeekFront() (T, bool)
+	// PeekBack returns the back of the deque, false if it is empty
+	PeekBack() (T, bool)
+	Count() int
+}
+
+// deque extends the linked queue, the back is its tail and the front
+// its head
+type deque[T any] struct {
+	queue[T]
+}
+
+func (d *deque[T]) PushFront(val T) {
+	if d.count == 0 {
+		d.Enqueue(val)
+		return
+	}
+	newNode := &queueNode[T]{next: d.head, data: val}
+	d.head.prev = newNode
+	d.head = newNode
+	d.count++
+}
+
+func (d *deque[T]) PushBack(val T) {
+	d.Enqueue(val)
+}
+
+func (d *deque[T]) PopFront() (T, bool) {
+	return d.Dequeue()
+}
+
+func (d *deque[T]) PopBack() (T, bool) {
+	var result T
+	if d.tail == nil {
+		return result, false
+	}
+
+	result = d.tail.data
+	d.tail = d.tail.prev
+
+	if d.tail == nil {
+		d.head = nil
+	} else {
+		d.tail.next = nil
+	}
+	d.count--
+	return result, true
+}
+
+func (d *deque[T]) PeekFront() (T, bool) {
+	return d.Peek()
+}
+
+func (d *deque[T]) PeekBack() (T, bool) {
+	var result T
+	if d.tail == nil {
+		return result, false
+	}
+	return d.tail.data, true
+}
+
+// NewDeque constructs a new empty deque
+func NewDeque[T any]() Deque[T] {
+	return new(deque[T])
+}
@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// Concurrent is a Queue which is safe for concurrent use by multiple
+// producers and consumers.  Peek, Count and iteration are only snapshots
+// while other goroutines are using the queue, and the bulk operations are
+// not atomic.
+type Concurrent[T any] interface {
+	Queue[T]
+}
+
+type lockFreeNode[T any] struct {
+	next atomic.Pointer[lockFreeNode[T]]
+	data T
+}
+
+// lockFree is a Michael-Scott queue.  head always points at a dummy node
+// whose successor is the front of the queue, and tail lags at most one
+// node behind the back.  Nodes are never reused so the garbage collector
+// rules out ABA problems.
+type lockFree[T any] struct {
+	head, tail atomic.Pointer[lockFreeNode[T]]
+	count      atomic.Int64
+}
+
+func (q *lockFree[T]) Enqueue(val T) {
+	newNode := &lockFreeNode[T]{data: val}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// help a slow producer swing the tail forward
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, newNode) {
+			q.tail.CompareAndSwap(tail, newNode)
+			break
+		}
+	}
+	q.count.Add(1)
+}
+
+func (q *lockFree[T]) Dequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// next becomes the new dummy, its data is never written again
+		if q.head.CompareAndSwap(head, next) {
+			q.count.Add(-1)
+			return next.data, true
+		}
+	}
+}
+
+func (q *lockFree[T]) Peek() (T, bool) {
+	next := q.head.Load().next.Load()
+	if next == nil {
+		var zero T
+		return zero, false
+	}
+	return next.data, true
+}
+
+func (q *lockFree[T]) Count() int {
+	// a dequeue can be counted before the matching enqueue
+	return int(max(q.count.Load(), 0))
+}
+
+func (q *lockFree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := q.head.Load().next.Load(); n != nil; n = n.next.Load() {
+			if !yield(n.data) {
+				return
+			}
+		}
+	}
+}
+
+func (q *lockFree[T]) ToSlice() []T {
+	result := make([]T, 0, q.Count())
+	for val := range q.All() {
+		result = append(result, val)
+	}
+	return result
+}
+
+func (q *lockFree[T]) EnqueueAll(vals ...T) {
+	for _, val := range vals {
+		q.Enqueue(val)
+	}
+}
+
+func (q *lockFree[T]) Clear() {
+	for {
+		if _, ok := q.Dequeue(); !ok {
+			return
+		}
+	}
+}
+
+// NewLockFree constructs a new unbounded lock-free queue
+func NewLockFree[T any]() Concurrent[T] {
+	q := new(lockFree[T])
+	dummy := new(lockFreeNode[T])
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
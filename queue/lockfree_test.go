@@ -0,0 +1,111 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLockFreeSequential(t *testing.T) {
+	q := NewLockFree[int]()
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Expected Dequeue to fail on an empty queue")
+	}
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	if q.Count() != 100 {
+		t.Error("Expected 100 sized Queue but found ", q.Count())
+	}
+	for i := 0; i < 100; i++ {
+		if val, ok := q.Peek(); !ok || val != i {
+			t.Errorf("Expected peek value of %d but found %d", i, val)
+		}
+		if val, ok := q.Dequeue(); !ok || val != i {
+			t.Errorf("Expected dequeue value of %d but found %d", i, val)
+		}
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("Expected Peek to fail on an empty queue")
+	}
+}
+
+// TestLockFreeStress checks every value comes out exactly once and that
+// each producer's values come out in the order they went in
+func TestLockFreeStress(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 5000
+	q := NewLockFree[[2]int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue([2]int{p, i})
+			}
+		}(p)
+	}
+
+	results := make([][][2]int, consumers)
+	var done sync.WaitGroup
+	var remaining sync.WaitGroup
+	remaining.Add(producers * perProducer)
+	stop := make(chan struct{})
+	for c := 0; c < consumers; c++ {
+		done.Add(1)
+		go func(c int) {
+			defer done.Done()
+			for {
+				if val, ok := q.Dequeue(); ok {
+					results[c] = append(results[c], val)
+					remaining.Done()
+					continue
+				}
+				select {
+				case <-stop:
+					return
+				default:
+				}
+			}
+		}(c)
+	}
+
+	wg.Wait()
+	remaining.Wait()
+	close(stop)
+	done.Wait()
+
+	seen := make([][]bool, producers)
+	for p := range seen {
+		seen[p] = make([]bool, perProducer)
+	}
+	for _, result := range results {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, val := range result {
+			p, i := val[0], val[1]
+			if seen[p][i] {
+				t.Fatalf("Value %v was dequeued twice", val)
+			}
+			if i <= last[p] {
+				t.Fatalf("Value %v was dequeued after %d", val, last[p])
+			}
+			seen[p][i], last[p] = true, i
+		}
+	}
+	if q.Count() != 0 {
+		t.Errorf("Expected empty queue but found %d", q.Count())
+	}
+}
+
+func BenchmarkLockFreeQueue(b *testing.B) {
+	q := NewLockFree[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(1)
+			q.Dequeue()
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package queue
+
+// Meldable is a PriorityQueue which can absorb another of its kind
+type Meldable[T any] interface {
+	PriorityQueue[T]
+	// Meld moves every value of other into this queue in O(1), leaving
+	// other empty.  Handles from other stay valid and now refer to this
+	// queue.  Both queues must use the same ordering, false if other
+	// cannot be melded.
+	Meld(other Meldable[T]) bool
+}
+
+// membership identifies which heap a node belongs to.  Melding forwards
+// the absorbed heap's membership so its nodes never need to be visited.
+type membership struct {
+	merged *membership
+}
+
+func (m *membership) resolve() *membership {
+	for m.merged != nil {
+		if m.merged.merged != nil {
+			m.merged = m.merged.merged
+		}
+		m = m.merged
+	}
+	return m
+}
+
+type pairingNode[T any] struct {
+	value T
+	// prev is the parent for the first child, otherwise the left sibling
+	child, sibling, prev *pairingNode[T]
+	member               *membership
+}
+
+func (n *pairingNode[T]) Value() T {
+	return n.value
+}
+
+// pairingHeap is a heap ordered tree where every node keeps its children
+// in a linked list, giving O(1) Push, Meld and amortized decrease-key
+type pairingHeap[T any] struct {
+	root    *pairingNode[T]
+	size    int
+	less    func(a, b T) bool
+	member  *membership
+	scratch []*pairingNode[T]
+}
+
+// link makes the larger of two roots the first child of the other
+func (h *pairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.value, a.value) {
+		a, b = b, a
+	}
+	b.prev, b.sibling = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// detach cuts the subtree at n, which is not the root, from its parent
+func (h *pairingHeap[T]) detach(n *pairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+// pair merges a list of siblings into one tree, linking them in pairs from
+// the left and then folding the pairs together from the right
+func (h *pairingHeap[T]) pair(first *pairingNode[T]) *pairingNode[T] {
+	pairs := h.scratch[:0]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	var result *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		result = h.link(pairs[i], result)
+		pairs[i] = nil
+	}
+	h.scratch = pairs[:0]
+	return result
+}
+
+// cut takes n out of the heap leaving its children behind
+func (h *pairingHeap[T]) cut(n *pairingNode[T]) {
+	if n == h.root {
+		h.root = h.pair(n.child)
+	} else {
+		h.detach(n)
+		h.root = h.link(h.root, h.pair(n.child))
+	}
+	n.child = nil
+	h.size--
+}
+
+func (h *pairingHeap[T]) mine(handle Handle[T]) (*pairingNode[T], bool) {
+	n, ok := handle.(*pairingNode[T])
+	return n, ok && n.member != nil && n.member.resolve() == h.member
+}
+
+func (h *pairingHeap[T]) Push(val T) Handle[T] {
+	n := &pairingNode[T]{value: val, member: h.member}
+	h.root = h.link(h.root, n)
+	h.size++
+	return n
+}
+
+func (h *pairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := h.root
+	h.cut(n)
+	n.member = nil
+	return n.value, true
+}
+
+func (h *pairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+func (h *pairingHeap[T]) Len() int {
+	return h.size
+}
+
+func (h *pairingHeap[T]) Update(handle Handle[T], val T) bool {
+	n, ok := h.mine(handle)
+	if !ok {
+		return false
+	}
+
+	if h.less(n.value, val) {
+		// n may now be larger than its children, so reinsert it alone
+		h.cut(n)
+		n.value = val
+		h.root = h.link(h.root, n)
+		h.size++
+		return true
+	}
+
+	n.value = val
+	if n != h.root {
+		h.detach(n)
+		h.root = h.link(h.root, n)
+	}
+	return true
+}
+
+func (h *pairingHeap[T]) Remove(handle Handle[T]) bool {
+	n, ok := h.mine(handle)
+	if !ok {
+		return false
+	}
+	h.cut(n)
+	n.member = nil
+	return true
+}
+
+func (h *pairingHeap[T]) Meld(other Meldable[T]) bool {
+	o, ok := other.(*pairingHeap[T])
+	if !ok || o == h {
+		return false
+	}
+	h.root = h.link(h.root, o.root)
+	h.size += o.size
+	o.member.merged = h.member
+	o.root, o.size, o.member = nil, 0, new(membership)
+	return true
+}
+
+// NewPairingHeap constructs a new pairing heap ordered by less
+func NewPairingHeap[T any](less func(a, b T) bool) Meldable[T] {
+	return &pairingHeap[T]{less: less, member: new(membership)}
+}
@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"iter"
+	"sync"
+)
+
+// Persistent is an immutable queue, every update returns a new version
+// sharing structure with the old one.  Versions are safe for concurrent
+// use without locking.
+type Persistent[T any] interface {
+	// Enqueue returns a version with val added to the back
+	Enqueue(val T) Persistent[T]
+	// Dequeue returns the front and a version without it, false if the
+	// queue is empty
+	Dequeue() (T, Persistent[T], bool)
+	// Peek returns the front of the queue, false if it is empty
+	Peek() (T, bool)
+	Count() int
+	// All yields the values from front to back
+	All() iter.Seq[T]
+}
+
+type cell[T any] struct {
+	head T
+	tail *stream[T]
+}
+
+// stream is a lazily evaluated list, a nil stream is empty
+type stream[T any] struct {
+	once  sync.Once
+	thunk func() *cell[T]
+	cell  *cell[T]
+}
+
+func lazy[T any](thunk func() *cell[T]) *stream[T] {
+	return &stream[T]{thunk: thunk}
+}
+
+func evaluated[T any](c *cell[T]) *stream[T] {
+	s := new(stream[T])
+	s.once.Do(func() { s.cell = c })
+	return s
+}
+
+// force evaluates s once, returning nil if it is empty
+func (s *stream[T]) force() *cell[T] {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		s.cell = s.thunk()
+		s.thunk = nil
+	})
+	return s.cell
+}
+
+type list[T any] struct {
+	head T
+	tail *list[T]
+}
+
+// persistent is Okasaki's real-time queue.  front is a stream and rear a
+// reversed list with len(rear) <= len(front).  schedule is the unevaluated
+// suffix of front, forcing one cell of it per operation makes every
+// operation O(1) in the worst case, not just amortized.
+type persistent[T any] struct {
+	front    *stream[T]
+	rear     *list[T]
+	frontLen int
+	rearLen  int
+	schedule *stream[T]
+}
+
+// rotate lazily computes front ++ reverse(rear) ++ acc, which requires
+// len(rear) == len(front)+1
+func rotate[T any](front *stream[T], rear *list[T], acc *stream[T]) *stream[T] {
+	return lazy(func() *cell[T] {
+		c := front.force()
+		if c == nil {
+			return &cell[T]{head: rear.head, tail: acc}
+		}
+		return &cell[T]{
+			head: c.head,
+			tail: rotate(c.tail, rear.tail, evaluated(&cell[T]{head: rear.head, tail: acc})),
+		}
+	})
+}
+
+// exec advances the schedule, starting a rotation once it runs out
+func exec[T any](front *stream[T], frontLen int, rear *list[T], rearLen int, schedule *stream[T]) *persistent[T] {
+	if c := schedule.force(); c != nil {
+		return &persistent[T]{front, rear, frontLen, rearLen, c.tail}
+	}
+	front = rotate(front, rear, nil)
+	return &persistent[T]{front, nil, frontLen + rearLen, 0, front}
+}
+
+func (q *persistent[T]) Enqueue(val T) Persistent[T] {
+	return exec(q.front, q.frontLen, &list[T]{val, q.rear}, q.rearLen+1, q.schedule)
+}
+
+func (q *persistent[T]) Dequeue() (T, Persistent[T], bool) {
+	c := q.front.force()
+	if c == nil {
+		var zero T
+		return zero, q, false
+	}
+	return c.head, exec(c.tail, q.frontLen-1, q.rear, q.rearLen, q.schedule), true
+}
+
+func (q *persistent[T]) Peek() (T, bool) {
+	if c := q.front.force(); c != nil {
+		return c.head, true
+	}
+	var zero T
+	return zero, false
+}
+
+func (q *persistent[T]) Count() int {
+	return q.frontLen + q.rearLen
+}
+
+func (q *persistent[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for c := q.front.force(); c != nil; c = c.tail.force() {
+			if !yield(c.head) {
+				return
+			}
+		}
+		rear := make([]T, 0, q.rearLen)
+		for l := q.rear; l != nil; l = l.tail {
+			rear = append(rear, l.head)
+		}
+		for i := len(rear) - 1; i >= 0; i-- {
+			if !yield(rear[i]) {
+				return
+			}
+		}
+	}
+}
+
+// NewPersistent constructs a new empty immutable queue
+func NewPersistent[T any]() Persistent[T] {
+	return new(persistent[T])
+}
@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistentFIFO(t *testing.T) {
+	q := NewPersistent[int]()
+	if _, _, ok := q.Dequeue(); ok {
+		t.Error("Expected Dequeue to fail on an empty queue")
+	}
+	for i := 0; i < 100; i++ {
+		q = q.Enqueue(i)
+	}
+	if q.Count() != 100 {
+		t.Error("Expected 100 sized Queue but found ", q.Count())
+	}
+	for i := 0; i < 100; i++ {
+		if val, ok := q.Peek(); !ok || val != i {
+			t.Fatalf("Expected peek value of %d but found %d", i, val)
+		}
+		val, next, ok := q.Dequeue()
+		if !ok || val != i {
+			t.Fatalf("Expected dequeue value of %d but found %d", i, val)
+		}
+		q = next
+		if i%3 == 0 {
+			q = q.Enqueue(100 + i)
+		}
+	}
+	for i := 0; i < 100; i += 3 {
+		val, next, _ := q.Dequeue()
+		if val != 100+i {
+			t.Fatalf("Expected dequeue value of %d but found %d", 100+i, val)
+		}
+		q = next
+	}
+	if q.Count() != 0 {
+		t.Errorf("Expected empty queue but found %d", q.Count())
+	}
+}
+
+func TestPersistentVersions(t *testing.T) {
+	var versions []Persistent[int]
+	q := NewPersistent[int]()
+	for i := 0; i < 20; i++ {
+		versions = append(versions, q)
+		q = q.Enqueue(i)
+	}
+
+	// branching from an old version leaves the others untouched
+	_, branch, _ := versions[10].Dequeue()
+	branch = branch.Enqueue(-1)
+
+	for i, v := range versions {
+		expected := make([]int, i)
+		for j := range expected {
+			expected[j] = j
+		}
+		if found := slices.Collect(v.All()); !slices.Equal(found, expected) {
+			t.Fatalf("Version %d: Expected %v but found %v", i, expected, found)
+		}
+		if v.Count() != i {
+			t.Fatalf("Version %d: Expected %d values but found %d", i, i, v.Count())
+		}
+	}
+
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, -1}
+	if found := slices.Collect(branch.All()); !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+}
+
+func TestPersistentConcurrentReaders(t *testing.T) {
+	q := NewPersistent[int]()
+	for i := 0; i < 1000; i++ {
+		q = q.Enqueue(i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			snapshot := q
+			for i := 0; i < 1000; i++ {
+				val, next, ok := snapshot.Dequeue()
+				if !ok || val != i {
+					t.Errorf("Expected dequeue value of %d but found %d", i, val)
+					return
+				}
+				snapshot = next.Enqueue(val)
+			}
+		}()
+	}
+	wg.Wait()
+}
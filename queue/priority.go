@@ -0,0 +1,156 @@
+package queue
+
+// Handle refers to a value stored in a PriorityQueue so it can later be
+// updated or removed
+type Handle[T any] interface {
+	Value() T
+}
+
+// PriorityQueue always gives back the least value according to the
+// comparator it was constructed with
+type PriorityQueue[T any] interface {
+	// Push adds val, returning a handle to it
+	Push(val T) Handle[T]
+	// Pop removes the least value, false if the queue is empty
+	Pop() (T, bool)
+	// Peek returns the least value, false if the queue is empty
+	Peek() (T, bool)
+	Len() int
+	// Update replaces the value behind h and restores the ordering,
+	// false if h is not in this queue
+	Update(h Handle[T], val T) bool
+	// Remove deletes the value behind h, false if h is not in this queue
+	Remove(h Handle[T]) bool
+}
+
+type heapItem[T any] struct {
+	value T
+	index int
+	owner *binaryHeap[T]
+}
+
+func (i *heapItem[T]) Value() T {
+	return i.value
+}
+
+// binaryHeap is an implicit binary min-heap, every item knows its index
+// so handles can be repositioned
+type binaryHeap[T any] struct {
+	items []*heapItem[T]
+	less  func(a, b T) bool
+}
+
+func (h *binaryHeap[T]) lessAt(i, j int) bool {
+	return h.less(h.items[i].value, h.items[j].value)
+}
+
+func (h *binaryHeap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *binaryHeap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.lessAt(i, parent) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+func (h *binaryHeap[T]) down(i int) {
+	for {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < len(h.items) && h.lessAt(left, smallest) {
+			smallest = left
+		}
+		if right < len(h.items) && h.lessAt(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// fix restores the ordering after the item at i changed
+func (h *binaryHeap[T]) fix(i int) {
+	h.up(i)
+	h.down(i)
+}
+
+// mine returns the item behind handle if it belongs to this heap
+func (h *binaryHeap[T]) mine(handle Handle[T]) (*heapItem[T], bool) {
+	item, ok := handle.(*heapItem[T])
+	return item, ok && item.owner == h && item.index >= 0
+}
+
+func (h *binaryHeap[T]) Push(val T) Handle[T] {
+	item := &heapItem[T]{value: val, index: len(h.items), owner: h}
+	h.items = append(h.items, item)
+	h.up(item.index)
+	return item
+}
+
+// removeAt takes the item at i out of the heap
+func (h *binaryHeap[T]) removeAt(i int) T {
+	last := len(h.items) - 1
+	item := h.items[i]
+	h.swap(i, last)
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i < last {
+		h.fix(i)
+	}
+	item.index = -1
+	return item.value
+}
+
+func (h *binaryHeap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+func (h *binaryHeap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0].value, true
+}
+
+func (h *binaryHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *binaryHeap[T]) Update(handle Handle[T], val T) bool {
+	item, ok := h.mine(handle)
+	if !ok {
+		return false
+	}
+	item.value = val
+	h.fix(item.index)
+	return true
+}
+
+func (h *binaryHeap[T]) Remove(handle Handle[T]) bool {
+	item, ok := h.mine(handle)
+	if !ok {
+		return false
+	}
+	h.removeAt(item.index)
+	return true
+}
+
+// NewPriorityQueue constructs a new binary heap ordered by less
+func NewPriorityQueue[T any](less func(a, b T) bool) PriorityQueue[T] {
+	return &binaryHeap[T]{less: less}
+}
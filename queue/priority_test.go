@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+// testPriorityQueue pushes, updates and removes random values and checks
+// everything comes out sorted
+func testPriorityQueue(t *testing.T, pq PriorityQueue[int]) {
+	r := rand.New(rand.NewSource(7))
+	var expected []int
+	var handles []Handle[int]
+
+	if _, ok := pq.Pop(); ok {
+		t.Error("Expected Pop to fail on an empty queue")
+	}
+	for i := 0; i < 500; i++ {
+		handles = append(handles, pq.Push(r.Intn(1000)))
+	}
+
+	// decrease, increase and remove a third each
+	for i, h := range handles {
+		switch i % 3 {
+		case 0:
+			if !pq.Update(h, h.Value()-r.Intn(1000)) {
+				t.Fatal("Expected Update to succeed")
+			}
+		case 1:
+			if !pq.Update(h, h.Value()+r.Intn(1000)) {
+				t.Fatal("Expected Update to succeed")
+			}
+		case 2:
+			if !pq.Remove(h) {
+				t.Fatal("Expected Remove to succeed")
+			}
+			if pq.Remove(h) || pq.Update(h, 0) {
+				t.Fatal("Expected a removed handle to be rejected")
+			}
+			continue
+		}
+		expected = append(expected, h.Value())
+	}
+	slices.Sort(expected)
+
+	if pq.Len() != len(expected) {
+		t.Fatalf("Expected %d values but found %d", len(expected), pq.Len())
+	}
+	for _, e := range expected {
+		if val, ok := pq.Peek(); !ok || val != e {
+			t.Fatalf("Expected peek value of %d but found %d", e, val)
+		}
+		if val, ok := pq.Pop(); !ok || val != e {
+			t.Fatalf("Expected pop value of %d but found %d", e, val)
+		}
+	}
+	if _, ok := pq.Peek(); ok {
+		t.Error("Expected Peek to fail on an empty queue")
+	}
+	if pq.Update(handles[0], 1) {
+		t.Error("Expected a popped handle to be rejected")
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	testPriorityQueue(t, NewPriorityQueue(intLess))
+}
+
+func TestPriorityQueueForeignHandle(t *testing.T) {
+	a, b := NewPriorityQueue(intLess), NewPriorityQueue(intLess)
+	h := a.Push(1)
+	if b.Update(h, 2) || b.Remove(h) {
+		t.Error("Expected a handle from another queue to be rejected")
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	testPriorityQueue(t, NewPairingHeap(intLess))
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	a, b, c := NewPairingHeap(intLess), NewPairingHeap(intLess), NewPairingHeap(intLess)
+	var handles []Handle[int]
+	for i := 0; i < 10; i++ {
+		handles = append(handles, a.Push(2*i), b.Push(2*i+1))
+	}
+	c.Push(100)
+
+	if !a.Meld(b) || !c.Meld(a) {
+		t.Fatal("Expected Meld to succeed")
+	}
+	if a.Meld(a) || a.Meld(nil) {
+		t.Error("Expected Meld with itself or nil to fail")
+	}
+	if a.Len() != 0 || b.Len() != 0 || c.Len() != 21 {
+		t.Fatalf("Expected sizes 0, 0, 21 but found %d, %d, %d", a.Len(), b.Len(), c.Len())
+	}
+
+	// handles from both melded heaps now belong to c
+	if a.Update(handles[1], -1) || b.Remove(handles[1]) {
+		t.Error("Expected melded handles to be rejected by their old heaps")
+	}
+	if !c.Update(handles[1], -1) || !c.Remove(handles[0]) {
+		t.Error("Expected melded handles to be accepted by the new heap")
+	}
+
+	// the emptied heaps are still usable
+	a.Push(5)
+	if val, ok := a.Pop(); !ok || val != 5 {
+		t.Errorf("Expected pop value of 5 but found %d", val)
+	}
+
+	expected := []int{-1}
+	for i := 2; i < 20; i++ {
+		expected = append(expected, i)
+	}
+	expected = append(expected, 100)
+	for _, e := range expected {
+		if val, _ := c.Pop(); val != e {
+			t.Fatalf("Expected pop value of %d but found %d", e, val)
+		}
+	}
+}
+
+// benchmarkDecreaseKey mimics Dijkstra, most operations lower a key
+func benchmarkDecreaseKey(b *testing.B, newQueue func(func(a, b int) bool) PriorityQueue[int]) {
+	r := rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pq := newQueue(intLess)
+		handles := make([]Handle[int], 1000)
+		for j := range handles {
+			handles[j] = pq.Push(1 << 20)
+		}
+		for j := 0; j < 10000; j++ {
+			h := handles[r.Intn(len(handles))]
+			pq.Update(h, h.Value()-r.Intn(100))
+		}
+		for pq.Len() > 0 {
+			pq.Pop()
+		}
+	}
+}
+
+func BenchmarkBinaryHeapDecreaseKey(b *testing.B) {
+	benchmarkDecreaseKey(b, NewPriorityQueue[int])
+}
+
+func BenchmarkPairingHeapDecreaseKey(b *testing.B) {
+	benchmarkDecreaseKey(b, func(less func(a, b int) bool) PriorityQueue[int] {
+		return NewPairingHeap(less)
+	})
+}
@@ -1,68 +1,136 @@
 package queue
 
-type queueNode struct {
-	prev, next *queueNode
-	data       interface{}
+import "iter"
+
+type queueNode[T any] struct {
+	prev, next *queueNode[T]
+	data       T
 }
 
 // Queue gives required methods for a queue data-structure
-type Queue interface {
-	Enqueue(interface{})
-	Peek() interface{}
-	Dequeue() interface{}
+type Queue[T any] interface {
+	Enqueue(T)
+	// Peek returns the front of the queue, false if it is empty
+	Peek() (T, bool)
+	// Dequeue removes the front of the queue, false if it is empty
+	Dequeue() (T, bool)
 	Count() int
+
+	// All yields the values from front to back without removing them
+	All() iter.Seq[T]
+	// ToSlice returns the values from front to back
+	ToSlice() []T
+	// EnqueueAll adds every value in order
+	EnqueueAll(vals ...T)
+	// Clear removes every value
+	Clear()
 }
 
-type queue struct {
-	head, tail *queueNode
+type queue[T any] struct {
+	head, tail *queueNode[T]
 	count      int
 }
 
-//type queue []interface{}
-
-func (q *queue) Enqueue(val interface{}) {
+func (q *queue[T]) Enqueue(val T) {
 	if q.count == 0 {
-		newNode := &queueNode{}
+		newNode := &queueNode[T]{}
 		newNode.data = val
 		q.head, q.tail = newNode, newNode
 	} else {
-		newNode := &queueNode{prev: q.tail, data: val}
+		newNode := &queueNode[T]{prev: q.tail, data: val}
 		q.tail.next = newNode
 		q.tail = newNode
 	}
 	q.count++
 }
 
-func (q *queue) Dequeue() interface{} {
-	result := interface{}(nil)
-	if q.head != nil {
+func (q *queue[T]) Dequeue() (T, bool) {
+	var result T
+	if q.head == nil {
+		return result, false
+	}
+
+	result = q.head.data
+	q.head = q.head.next
+
+	if q.head == nil {
+		q.tail = nil
+	} else {
+		q.head.prev = nil
+	}
+	q.count--
+	return result, true
+}
+
+func (q *queue[T]) Peek() (T, bool) {
+	var result T
+	if q.head == nil {
+		return result, false
+	}
+	return q.head.data, true
+}
 
-		result = q.head.data
-		q.head = q.head.next
+func (q *queue[T]) Count() int {
+	return q.count
+}
 
-		if q.head == nil {
-			q.tail = nil
-		} else {
-			q.head.prev = nil
+func (q *queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := q.head; n != nil; n = n.next {
+			if !yield(n.data) {
+				return
+			}
 		}
-		q.count--
+	}
+}
+
+func (q *queue[T]) ToSlice() []T {
+	result := make([]T, 0, q.count)
+	for val := range q.All() {
+		result = append(result, val)
 	}
 	return result
 }
 
-func (q *queue) Peek() interface{} {
-	result := interface{}(nil)
-	if q.head != nil {
-		result = q.head.data
+func (q *queue[T]) EnqueueAll(vals ...T) {
+	for _, val := range vals {
+		q.Enqueue(val)
 	}
+}
+
+func (q *queue[T]) Clear() {
+	q.head, q.tail, q.count = nil, nil, 0
+}
+
+// NewOf constructs a new queue holding values of type T
+func NewOf[T any]() Queue[T] {
+	return new(queue[T])
+}
+
+// Untyped is the original queue interface storing interface{} values,
+// where an empty queue yields nil
+type Untyped interface {
+	Enqueue(interface{})
+	Peek() interface{}
+	Dequeue() interface{}
+	Count() int
+}
+
+type untyped struct {
+	Queue[interface{}]
+}
+
+func (u untyped) Peek() interface{} {
+	result, _ := u.Queue.Peek()
 	return result
 }
 
-func (q *queue) Count() int {
-	return q.count
+func (u untyped) Dequeue() interface{} {
+	result, _ := u.Queue.Dequeue()
+	return result
 }
 
-// New constructs a new queue
-func New() Queue {
-	return new(queue)
+// New constructs a new untyped queue
+func New() Untyped {
+	return untyped{NewOf[interface{}]()}
 }
@@ -1,6 +1,9 @@
 package queue
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestQueueNew(t *testing.T) {
 	q := New()
@@ -34,3 +37,128 @@ func TestQueueFillThenEmpty(t *testing.T) {
 		t.Error("Expected empty queue")
 	}
 }
+
+func TestQueueEmpty(t *testing.T) {
+	q := New()
+	if q.Peek() != nil || q.Dequeue() != nil {
+		t.Error("Expected nil from an empty queue")
+	}
+}
+
+func TestQueueOf(t *testing.T) {
+	q := NewOf[string]()
+	if _, ok := q.Peek(); ok {
+		t.Error("Expected Peek to fail on an empty queue")
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Expected Dequeue to fail on an empty queue")
+	}
+
+	words := []string{"a", "b", "c"}
+	for _, w := range words {
+		q.Enqueue(w)
+	}
+	for _, w := range words {
+		if val, ok := q.Peek(); !ok || val != w {
+			t.Errorf("Expected peek value of %q but found %q", w, val)
+		}
+		if val, ok := q.Dequeue(); !ok || val != w {
+			t.Errorf("Expected dequeue value of %q but found %q", w, val)
+		}
+	}
+	if q.Count() != 0 {
+		t.Error("Expected empty queue")
+	}
+}
+
+func TestDeque(t *testing.T) {
+	d := NewDeque[int]()
+	if _, ok := d.PopFront(); ok {
+		t.Error("Expected PopFront to fail on an empty deque")
+	}
+	if _, ok := d.PeekBack(); ok {
+		t.Error("Expected PeekBack to fail on an empty deque")
+	}
+
+	// builds 4 3 2 1 0 1 2 3 4 5
+	for i := 0; i < 5; i++ {
+		d.PushFront(i)
+		d.PushBack(i + 1)
+	}
+	if d.Count() != 10 {
+		t.Errorf("Expected 10 sized Deque but found %d", d.Count())
+	}
+	if val, _ := d.PeekFront(); val != 4 {
+		t.Errorf("Expected front of 4 but found %d", val)
+	}
+	if val, _ := d.PeekBack(); val != 5 {
+		t.Errorf("Expected back of 5 but found %d", val)
+	}
+
+	for i := 5; i > 0; i-- {
+		if val, ok := d.PopBack(); !ok || val != i {
+			t.Errorf("Expected PopBack value of %d but found %d", i, val)
+		}
+	}
+	for i := 4; i >= 0; i-- {
+		if val, ok := d.PopFront(); !ok || val != i {
+			t.Errorf("Expected PopFront value of %d but found %d", i, val)
+		}
+	}
+	if d.Count() != 0 {
+		t.Error("Expected empty deque")
+	}
+
+	d.PushBack(7)
+	if val, ok := d.PopBack(); !ok || val != 7 {
+		t.Errorf("Expected PopBack value of 7 but found %d", val)
+	}
+	if _, ok := d.PeekFront(); ok {
+		t.Error("Expected PeekFront to fail on an empty deque")
+	}
+}
+
+func TestQueueIteration(t *testing.T) {
+	queues := map[string]Queue[int]{
+		"linked":   NewOf[int](),
+		"ring":     NewRing[int](2),
+		"lockfree": NewLockFree[int](),
+	}
+	for name, q := range queues {
+		if s := q.ToSlice(); len(s) != 0 {
+			t.Errorf("%s: Expected an empty slice but found %v", name, s)
+		}
+
+		q.EnqueueAll(0, 1, 2)
+		q.Dequeue()
+		q.EnqueueAll(3, 4)
+
+		expected := []int{1, 2, 3, 4}
+		if s := q.ToSlice(); !slices.Equal(s, expected) {
+			t.Errorf("%s: Expected %v but found %v", name, expected, s)
+		}
+
+		var found []int
+		for val := range q.All() {
+			if val == 3 {
+				break
+			}
+			found = append(found, val)
+		}
+		if !slices.Equal(found, expected[:2]) {
+			t.Errorf("%s: Expected %v but found %v", name, expected[:2], found)
+		}
+		if q.Count() != len(expected) {
+			t.Errorf("%s: Iterating changed the count to %d", name, q.Count())
+		}
+
+		q.Clear()
+		if q.Count() != 0 || len(q.ToSlice()) != 0 {
+			t.Errorf("%s: Expected empty queue after Clear", name)
+		}
+		q.Enqueue(5)
+		if val, ok := q.Dequeue(); !ok || val != 5 {
+			t.Errorf("%s: Expected dequeue value of 5 but found %d", name, val)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package queue
+
+import "iter"
+
+const defaultRingCapacity = 16
+
+// ring is a queue stored in a circular slice which doubles in size
+// whenever it fills up
+type ring[T any] struct {
+	data  []T
+	head  int
+	count int
+}
+
+func (q *ring[T]) grow() {
+	data := make([]T, 2*len(q.data))
+	n := copy(data, q.data[q.head:])
+	copy(data[n:], q.data[:q.head])
+	q.data, q.head = data, 0
+}
+
+func (q *ring[T]) Enqueue(val T) {
+	if q.count == len(q.data) {
+		q.grow()
+	}
+	q.data[(q.head+q.count)%len(q.data)] = val
+	q.count++
+}
+
+func (q *ring[T]) Dequeue() (T, bool) {
+	var zero T
+	if q.count == 0 {
+		return zero, false
+	}
+
+	result := q.data[q.head]
+	// clear the slot so the queue does not keep the value alive
+	q.data[q.head] = zero
+	q.head = (q.head + 1) % len(q.data)
+	q.count--
+	return result, true
+}
+
+func (q *ring[T]) Peek() (T, bool) {
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.data[q.head], true
+}
+
+func (q *ring[T]) Count() int {
+	return q.count
+}
+
+func (q *ring[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.count; i++ {
+			if !yield(q.data[(q.head+i)%len(q.data)]) {
+				return
+			}
+		}
+	}
+}
+
+func (q *ring[T]) ToSlice() []T {
+	result := make([]T, 0, q.count)
+	for val := range q.All() {
+		result = append(result, val)
+	}
+	return result
+}
+
+func (q *ring[T]) EnqueueAll(vals ...T) {
+	for _, val := range vals {
+		q.Enqueue(val)
+	}
+}
+
+func (q *ring[T]) Clear() {
+	clear(q.data)
+	q.head, q.count = 0, 0
+}
+
+// NewRing constructs a new array backed queue with room for capacity
+// values before it has to grow.  A capacity <= 0 uses a small default.
+func NewRing[T any](capacity int) Queue[T] {
+	if capacity <= 0 {
+		capacity = defaultRingCapacity
+	}
+	return &ring[T]{data: make([]T, capacity)}
+}
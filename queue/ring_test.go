@@ -0,0 +1,67 @@
+package queue
+
+import "testing"
+
+func TestRingWrapsAndGrows(t *testing.T) {
+	q := NewRing[int](3)
+	next, expected := 0, 0
+
+	// interleave so head moves around the buffer before it grows
+	for round := 0; round < 50; round++ {
+		for i := 0; i < round%4+2; i++ {
+			q.Enqueue(next)
+			next++
+		}
+		for i := 0; i < round%3+1 && q.Count() > 0; i++ {
+			if val, ok := q.Peek(); !ok || val != expected {
+				t.Fatalf("Expected peek value of %d but found %d", expected, val)
+			}
+			if val, ok := q.Dequeue(); !ok || val != expected {
+				t.Fatalf("Expected dequeue value of %d but found %d", expected, val)
+			}
+			expected++
+		}
+		if q.Count() != next-expected {
+			t.Fatalf("Expected %d sized Queue but found %d", next-expected, q.Count())
+		}
+	}
+
+	for q.Count() > 0 {
+		if val, _ := q.Dequeue(); val != expected {
+			t.Fatalf("Expected dequeue value of %d but found %d", expected, val)
+		}
+		expected++
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Expected Dequeue to fail on an empty queue")
+	}
+}
+
+func TestRingDefaultCapacity(t *testing.T) {
+	q := NewRing[int](0)
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	if q.Count() != 100 {
+		t.Error("Expected 100 sized Queue but found ", q.Count())
+	}
+}
+
+func benchmarkQueue(b *testing.B, q Queue[int]) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// grow to a BFS sized frontier then drain it
+		for j := 0; j < 1000; j++ {
+			q.Enqueue(j)
+			if j%3 == 0 {
+				q.Dequeue()
+			}
+		}
+		for q.Count() > 0 {
+			q.Dequeue()
+		}
+	}
+}
+
+func BenchmarkLinkedQueue(b *testing.B) { benchmarkQueue(b, NewOf[int]()) }
+func BenchmarkRingQueue(b *testing.B)   { benchmarkQueue(b, NewRing[int](0)) }
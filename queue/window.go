@@ -0,0 +1,142 @@
+package queue
+
+// Window is a queue holding at most Capacity values, enqueueing into a full
+// window evicts its oldest value
+type Window[T any] interface {
+	Queue[T]
+	Capacity() int
+}
+
+// Rolling is a Window which also tracks its least and greatest values
+type Rolling[T any] interface {
+	Window[T]
+	// Min returns the least value in the window, false if it is empty
+	Min() (T, bool)
+	// Max returns the greatest value in the window, false if it is empty
+	Max() (T, bool)
+}
+
+type window[T any] struct {
+	ring[T]
+	onEvict func(T)
+}
+
+func (w *window[T]) Enqueue(val T) {
+	if w.count == len(w.data) {
+		old, _ := w.ring.Dequeue()
+		if w.onEvict != nil {
+			w.onEvict(old)
+		}
+	}
+	w.ring.Enqueue(val)
+}
+
+func (w *window[T]) EnqueueAll(vals ...T) {
+	for _, val := range vals {
+		w.Enqueue(val)
+	}
+}
+
+func (w *window[T]) Capacity() int {
+	return len(w.data)
+}
+
+type rollingEntry[T any] struct {
+	value T
+	seq   int
+}
+
+// rolling keeps a monotonic deque for each extreme.  Every value is tagged
+// with its position in the stream, a deque's front is dropped once that
+// position leaves the window and its back whenever a newer value beats it.
+type rolling[T any] struct {
+	window[T]
+	less       func(a, b T) bool
+	seq        int
+	mins, maxs deque[rollingEntry[T]]
+}
+
+// push adds e to the back of d, first dropping every entry which can no
+// longer be the extreme because e is newer and not beaten by it
+func (r *rolling[T]) push(d *deque[rollingEntry[T]], e rollingEntry[T], beats func(a, b T) bool) {
+	for {
+		back, ok := d.PeekBack()
+		if !ok || beats(back.value, e.value) {
+			break
+		}
+		d.PopBack()
+	}
+	d.PushBack(e)
+}
+
+// trim drops entries which have left the window
+func (r *rolling[T]) trim() {
+	oldest := r.seq - r.count
+	for _, d := range []*deque[rollingEntry[T]]{&r.mins, &r.maxs} {
+		for {
+			front, ok := d.PeekFront()
+			if !ok || front.seq >= oldest {
+				break
+			}
+			d.PopFront()
+		}
+	}
+}
+
+func (r *rolling[T]) Enqueue(val T) {
+	r.window.Enqueue(val)
+	e := rollingEntry[T]{value: val, seq: r.seq}
+	r.seq++
+	r.push(&r.mins, e, r.less)
+	r.push(&r.maxs, e, func(a, b T) bool { return r.less(b, a) })
+	r.trim()
+}
+
+func (r *rolling[T]) EnqueueAll(vals ...T) {
+	for _, val := range vals {
+		r.Enqueue(val)
+	}
+}
+
+func (r *rolling[T]) Dequeue() (T, bool) {
+	result, ok := r.window.Dequeue()
+	r.trim()
+	return result, ok
+}
+
+func (r *rolling[T]) Clear() {
+	r.window.Clear()
+	r.mins.Clear()
+	r.maxs.Clear()
+}
+
+func (r *rolling[T]) Min() (T, bool) {
+	front, ok := r.mins.PeekFront()
+	return front.value, ok
+}
+
+func (r *rolling[T]) Max() (T, bool) {
+	front, ok := r.maxs.PeekFront()
+	return front.value, ok
+}
+
+func newWindow[T any](capacity int, onEvict func(T)) window[T] {
+	if capacity <= 0 {
+		panic("window capacity must be positive")
+	}
+	return window[T]{ring: ring[T]{data: make([]T, capacity)}, onEvict: onEvict}
+}
+
+// NewWindow constructs a new window holding at most capacity values.
+// onEvict, if not nil, is called with every value pushed out by Enqueue.
+func NewWindow[T any](capacity int, onEvict func(T)) Window[T] {
+	w := newWindow(capacity, onEvict)
+	return &w
+}
+
+// NewRolling constructs a new window holding at most capacity values whose
+// extremes are ordered by less.  onEvict, if not nil, is called with every
+// value pushed out by Enqueue.
+func NewRolling[T any](capacity int, less func(a, b T) bool, onEvict func(T)) Rolling[T] {
+	return &rolling[T]{window: newWindow(capacity, onEvict), less: less}
+}
@@ -0,0 +1,80 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestWindowEvicts(t *testing.T) {
+	var evicted []int
+	w := NewWindow(3, func(v int) { evicted = append(evicted, v) })
+	if w.Capacity() != 3 {
+		t.Errorf("Expected capacity 3 but found %d", w.Capacity())
+	}
+
+	w.EnqueueAll(1, 2, 3, 4)
+	w.Enqueue(5)
+	if s := w.ToSlice(); !slices.Equal(s, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5] but found %v", s)
+	}
+	if !slices.Equal(evicted, []int{1, 2}) {
+		t.Errorf("Expected [1 2] to be evicted but found %v", evicted)
+	}
+
+	// dequeueing is not an eviction
+	w.Dequeue()
+	w.Enqueue(6)
+	if !slices.Equal(evicted, []int{1, 2}) {
+		t.Errorf("Expected [1 2] to be evicted but found %v", evicted)
+	}
+
+	NewWindow[int](1, nil).EnqueueAll(1, 2)
+}
+
+func TestRollingMinMax(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	w := NewRolling(5, intLess, nil)
+
+	if _, ok := w.Min(); ok {
+		t.Error("Expected Min to fail on an empty window")
+	}
+
+	var expected []int
+	for i := 0; i < 1000; i++ {
+		if r.Intn(4) == 0 {
+			w.Dequeue()
+			if len(expected) > 0 {
+				expected = expected[1:]
+			}
+		} else {
+			v := r.Intn(20)
+			w.Enqueue(v)
+			expected = append(expected, v)
+			if len(expected) > 5 {
+				expected = expected[1:]
+			}
+		}
+
+		min, minOk := w.Min()
+		max, maxOk := w.Max()
+		if len(expected) == 0 {
+			if minOk || maxOk {
+				t.Fatal("Expected Min and Max to fail on an empty window")
+			}
+			continue
+		}
+		if min != slices.Min(expected) || max != slices.Max(expected) {
+			t.Fatalf("%v: Expected (%d,%d) but found (%d,%d)", expected, slices.Min(expected), slices.Max(expected), min, max)
+		}
+	}
+
+	w.Clear()
+	if _, ok := w.Max(); ok || w.Count() != 0 {
+		t.Error("Expected empty window after Clear")
+	}
+	w.EnqueueAll(4, 2, 8)
+	if min, _ := w.Min(); min != 2 {
+		t.Errorf("Expected min of 2 but found %d", min)
+	}
+}
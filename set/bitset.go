@@ -0,0 +1,155 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// BitSet is a dense set of non-negative integers with one bit per value,
+// it grows as needed.  The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet returns an empty set with room for values below n
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, (n+63)/64)}
+}
+
+func bitPosition(i int) (int, uint64) {
+	if i < 0 {
+		panic("bitset index must be non-negative")
+	}
+	return i / 64, 1 << (i % 64)
+}
+
+func (b *BitSet) grow(word int) {
+	if word >= len(b.words) {
+		b.words = append(b.words, make([]uint64, word+1-len(b.words))...)
+	}
+}
+
+// Set adds i to the set
+func (b *BitSet) Set(i int) {
+	word, mask := bitPosition(i)
+	b.grow(word)
+	b.words[word] |= mask
+}
+
+// Clear removes i from the set
+func (b *BitSet) Clear(i int) {
+	if word, mask := bitPosition(i); word < len(b.words) {
+		b.words[word] &^= mask
+	}
+}
+
+// Test reports whether i is in the set
+func (b *BitSet) Test(i int) bool {
+	word, mask := bitPosition(i)
+	return word < len(b.words) && b.words[word]&mask != 0
+}
+
+// Flip adds i if it is missing, otherwise removes it
+func (b *BitSet) Flip(i int) {
+	word, mask := bitPosition(i)
+	b.grow(word)
+	b.words[word] ^= mask
+}
+
+// Count returns the number of values in the set
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// NextSet returns the least value in the set which is >= i, false if
+// there is none
+func (b *BitSet) NextSet(i int) (int, bool) {
+	word, _ := bitPosition(i)
+	if word >= len(b.words) {
+		return 0, false
+	}
+	// ignore the bits below i in its word
+	w := b.words[word] >> (i % 64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for word++; word < len(b.words); word++ {
+		if b.words[word] != 0 {
+			return word*64 + bits.TrailingZeros64(b.words[word]), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the least value not in the set which is >= i
+func (b *BitSet) NextClear(i int) int {
+	word, _ := bitPosition(i)
+	if word >= len(b.words) {
+		return i
+	}
+	w := ^b.words[word] >> (i % 64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w)
+	}
+	for word++; word < len(b.words); word++ {
+		if b.words[word] != ^uint64(0) {
+			return word*64 + bits.TrailingZeros64(^b.words[word])
+		}
+	}
+	return len(b.words) * 64
+}
+
+// All yields the values in ascending order
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				if !yield(i*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// combine applies op a word at a time, words missing from the shorter
+// set count as zero
+func (b *BitSet) combine(o *BitSet, op func(x, y uint64) uint64) *BitSet {
+	result := &BitSet{words: make([]uint64, max(len(b.words), len(o.words)))}
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(o.words) {
+			y = o.words[i]
+		}
+		result.words[i] = op(x, y)
+	}
+	return result
+}
+
+// And returns a new set with the values in both sets
+func (b *BitSet) And(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new set with the values in either set
+func (b *BitSet) Or(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new set with the values in exactly one of the sets
+func (b *BitSet) Xor(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new set with the values in b but not in o
+func (b *BitSet) AndNot(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x &^ y })
+}
@@ -0,0 +1,85 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	var b BitSet
+	if b.Test(5) || b.Count() != 0 {
+		t.Error("Expected the zero BitSet to be empty")
+	}
+	if _, ok := b.NextSet(0); ok {
+		t.Error("Expected NextSet to fail on an empty set")
+	}
+
+	for _, i := range []int{0, 3, 63, 64, 130} {
+		b.Set(i)
+	}
+	b.Flip(3)
+	b.Flip(200)
+	b.Clear(130)
+	b.Clear(1000)
+
+	expected := []int{0, 63, 64, 200}
+	if found := slices.Collect(b.All()); !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+	if b.Count() != len(expected) {
+		t.Errorf("Expected %d values but found %d", len(expected), b.Count())
+	}
+	if !b.Test(63) || b.Test(3) || b.Test(5000) {
+		t.Error("Test disagreed with the contents")
+	}
+
+	var found []int
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		found = append(found, i)
+	}
+	if !slices.Equal(found, expected) {
+		t.Errorf("NextSet: Expected %v but found %v", expected, found)
+	}
+
+	clearTests := map[int]int{0: 1, 63: 65, 64: 65, 199: 199, 200: 201, 500: 500}
+	for from, expected := range clearTests {
+		if found := b.NextClear(from); found != expected {
+			t.Errorf("NextClear(%d): Expected %d Found: %d", from, expected, found)
+		}
+	}
+
+	full := NewBitSet(128)
+	for i := 0; i < 128; i++ {
+		full.Set(i)
+	}
+	if found := full.NextClear(0); found != 128 {
+		t.Errorf("NextClear on a full set: Expected 128 Found: %d", found)
+	}
+}
+
+func TestBitSetOperations(t *testing.T) {
+	a, b := NewBitSet(0), NewBitSet(0)
+	for _, i := range []int{1, 2, 70, 300} {
+		a.Set(i)
+	}
+	for _, i := range []int{2, 70, 71} {
+		b.Set(i)
+	}
+
+	tests := []struct {
+		op       string
+		found    *BitSet
+		expected []int
+	}{
+		{"And", a.And(b), []int{2, 70}},
+		{"Or", a.Or(b), []int{1, 2, 70, 71, 300}},
+		{"Xor", a.Xor(b), []int{1, 71, 300}},
+		{"AndNot", a.AndNot(b), []int{1, 300}},
+		{"AndNot", b.AndNot(a), []int{71}},
+	}
+	for _, test := range tests {
+		if found := slices.Collect(test.found.All()); !slices.Equal(found, test.expected) {
+			t.Errorf("%s : Expected: %v Found: %v", test.op, test.expected, found)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package set
+
+import (
+	"cmp"
+	"slices"
+)
+
+// gallopRatio is how many times larger one input must be before galloping
+// beats the linear merge, around 8 on amd64 per BenchmarkIntersectCrossover
+const gallopRatio = 8
+
+// merge intersects a and b by stepping through both, in O(len(a) + len(b))
+func merge[T cmp.Ordered](a, b, result []T) []T {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case b[j] < a[i]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// gallop intersects small with large by exponential then binary search
+// for each element of small, in O(len(small) log len(large))
+func gallop[T cmp.Ordered](small, large, result []T) []T {
+	lo := 0
+	for _, v := range small {
+		if lo >= len(large) {
+			break
+		}
+		// everything before lo is less than v
+		bound := 1
+		for lo+bound < len(large) && large[lo+bound] < v {
+			bound *= 2
+		}
+		hi := min(lo+bound+1, len(large))
+		i, found := slices.BinarySearch(large[lo+bound/2:hi], v)
+		lo += bound/2 + i
+		if found {
+			result = append(result, v)
+			lo++
+		}
+	}
+	return result
+}
+
+// AdaptiveIntersect performs intersection of two sets, galloping through the
+// larger one when their sizes are skewed and merging linearly otherwise
+func AdaptiveIntersect(m *OrderedIntSet) {
+	m.Reset()
+	switch {
+	case len(m.first) >= gallopRatio*len(m.second):
+		m.result = gallop(m.second, m.first, m.result)
+	case len(m.second) >= gallopRatio*len(m.first):
+		m.result = gallop(m.first, m.second, m.result)
+	default:
+		m.result = merge(m.first, m.second, m.result)
+	}
+}
@@ -0,0 +1,117 @@
+package set
+
+import "iter"
+
+// Set is an unordered collection of distinct values backed by a map.
+// The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet returns a set containing vals
+func NewSet[T comparable](vals ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(vals))}
+	for _, v := range vals {
+		s.items[v] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts v, returning false if it was already present
+func (s *Set[T]) Add(v T) bool {
+	if s.Contains(v) {
+		return false
+	}
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+	s.items[v] = struct{}{}
+	return true
+}
+
+// Remove deletes v, returning false if it was not present
+func (s *Set[T]) Remove(v T) bool {
+	if !s.Contains(v) {
+		return false
+	}
+	delete(s.items, v)
+	return true
+}
+
+// Contains reports whether v is in the set
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.items[v]
+	return ok
+}
+
+// Len returns the number of values in the set
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// All yields every value in no particular order
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new set with the values in either set
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, max(s.Len(), o.Len()))}
+	for v := range s.items {
+		result.items[v] = struct{}{}
+	}
+	for v := range o.items {
+		result.items[v] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns a new set with the values in both sets
+func (s *Set[T]) Intersection(o *Set[T]) *Set[T] {
+	small, large := s, o
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	result := NewSet[T]()
+	for v := range small.items {
+		if large.Contains(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with the values in s but not in o
+func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for v := range s.items {
+		if !o.Contains(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every value in s is also in o
+func (s *Set[T]) IsSubset(o *Set[T]) bool {
+	if s.Len() > o.Len() {
+		return false
+	}
+	for v := range s.items {
+		if !o.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both sets hold the same values
+func (s *Set[T]) Equal(o *Set[T]) bool {
+	return s.Len() == o.Len() && s.IsSubset(o)
+}
@@ -0,0 +1,65 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func sorted(s *Set[int]) []int {
+	return slices.Sorted(s.All())
+}
+
+func TestHashSet(t *testing.T) {
+	var s Set[int]
+	if s.Len() != 0 || s.Contains(1) || s.Remove(1) {
+		t.Error("Expected the zero Set to be empty")
+	}
+	if !s.Add(1) || s.Add(1) || !s.Add(2) {
+		t.Error("Expected Add to report only new values")
+	}
+	if !s.Contains(1) || s.Len() != 2 {
+		t.Errorf("Expected {1 2} but found %v", sorted(&s))
+	}
+	if !s.Remove(1) || s.Contains(1) || s.Len() != 1 {
+		t.Errorf("Expected {2} but found %v", sorted(&s))
+	}
+}
+
+func TestHashSetAlgebra(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	empty := NewSet[int]()
+
+	tests := []struct {
+		op       string
+		found    *Set[int]
+		expected []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Intersection", b.Intersection(a), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference", b.Difference(a), []int{5}},
+		{"Union", a.Union(empty), []int{1, 2, 3, 4}},
+		{"Intersection", a.Intersection(empty), nil},
+	}
+	for _, test := range tests {
+		if found := sorted(test.found); !slices.Equal(found, test.expected) {
+			t.Errorf("%s : Expected: %v Found: %v", test.op, test.expected, found)
+		}
+	}
+
+	if found := sorted(a); !slices.Equal(found, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected the operations to leave a unchanged but found %v", found)
+	}
+
+	if !NewSet(3, 4).IsSubset(a) || !empty.IsSubset(a) || !a.IsSubset(a) {
+		t.Error("Expected IsSubset to be true")
+	}
+	if b.IsSubset(a) || a.IsSubset(empty) {
+		t.Error("Expected IsSubset to be false")
+	}
+	if !a.Equal(NewSet(4, 3, 2, 1, 1)) || a.Equal(b) || a.Equal(NewSet(1, 2, 3)) {
+		t.Error("Expected Equal to compare contents")
+	}
+}
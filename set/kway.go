@@ -0,0 +1,127 @@
+package set
+
+import "cmp"
+
+// cursors is a min-heap of the unread parts of sorted lists ordered by
+// their first element.  The least list is advanced and sifted down in
+// place, so merging allocates nothing per element.
+type cursors[T any] struct {
+	lists [][]T
+	less  func(a, b T) bool
+}
+
+// heads builds a heap of the non-empty lists
+func heads[T any](less func(a, b T) bool, lists [][]T) *cursors[T] {
+	c := &cursors[T]{less: less}
+	for _, l := range lists {
+		if len(l) > 0 {
+			c.lists = append(c.lists, l)
+		}
+	}
+	for i := len(c.lists)/2 - 1; i >= 0; i-- {
+		c.down(i)
+	}
+	return c
+}
+
+func (c *cursors[T]) lessAt(i, j int) bool {
+	return c.less(c.lists[i][0], c.lists[j][0])
+}
+
+func (c *cursors[T]) down(i int) {
+	for {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < len(c.lists) && c.lessAt(left, smallest) {
+			smallest = left
+		}
+		if right < len(c.lists) && c.lessAt(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		c.lists[i], c.lists[smallest] = c.lists[smallest], c.lists[i]
+		i = smallest
+	}
+}
+
+// top returns the least first element, the heap must not be empty
+func (c *cursors[T]) top() T {
+	return c.lists[0][0]
+}
+
+// advance steps past top, returning false if that used up its list
+func (c *cursors[T]) advance() bool {
+	rest := c.lists[0][1:]
+	ok := len(rest) > 0
+	if ok {
+		c.lists[0] = rest
+	} else {
+		last := len(c.lists) - 1
+		c.lists[0] = c.lists[last]
+		c.lists = c.lists[:last]
+	}
+	if len(c.lists) > 0 {
+		c.down(0)
+	}
+	return ok
+}
+
+// UnionAllFunc unions any number of sets, each sorted by less, in
+// O(n log k) for n elements across k sets
+func UnionAllFunc[T any](less func(a, b T) bool, lists ...[]T) []T {
+	var result []T
+	c := heads(less, lists)
+	for len(c.lists) > 0 {
+		v := c.top()
+		if len(result) == 0 || less(result[len(result)-1], v) {
+			result = append(result, v)
+		}
+		c.advance()
+	}
+	return result
+}
+
+// IntersectAllFunc intersects any number of sets, each sorted by less and
+// free of duplicates, in O(n log k) for n elements across k sets
+func IntersectAllFunc[T any](less func(a, b T) bool, lists ...[]T) []T {
+	var result []T
+	c := heads(less, lists)
+	if len(lists) == 0 || len(c.lists) < len(lists) {
+		return result
+	}
+
+	// equal elements come off the heap together, run counts how many
+	// sets the current one has been seen in
+	var current T
+	run, exhausted := 0, false
+	for len(c.lists) > 0 {
+		v := c.top()
+		if run > 0 && !less(current, v) {
+			run++
+		} else if exhausted {
+			// one of the sets is used up so nothing larger can be in all of them
+			break
+		} else {
+			current, run = v, 1
+		}
+
+		if run == len(lists) {
+			result = append(result, current)
+		}
+		if !c.advance() {
+			exhausted = true
+		}
+	}
+	return result
+}
+
+// UnionAll unions any number of sets sorted in ascending order
+func UnionAll[T cmp.Ordered](lists ...[]T) []T {
+	return UnionAllFunc(cmp.Less[T], lists...)
+}
+
+// IntersectAll intersects any number of sets sorted in ascending order
+func IntersectAll[T cmp.Ordered](lists ...[]T) []T {
+	return IntersectAllFunc(cmp.Less[T], lists...)
+}
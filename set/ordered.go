@@ -0,0 +1,86 @@
+package set
+
+import "cmp"
+
+// OrderedFuncSet allows for operations on sets of any type sorted
+// according to less
+type OrderedFuncSet[T any] struct {
+	first, second, result []T
+	posFirst, posSecond   int
+	less                  func(a, b T) bool
+}
+
+// NewOrderedFuncSet merges a and b which must both be sorted by less
+func NewOrderedFuncSet[T any](a, b []T, less func(a, b T) bool) *OrderedFuncSet[T] {
+	return &OrderedFuncSet[T]{first: a, second: b, less: less}
+}
+
+func (m *OrderedFuncSet[T]) GetResult() []T {
+	result := make([]T, len(m.result))
+	copy(result, m.result)
+	return result
+}
+
+// Less returns the relationship first[0] < second[0] if first is true,
+// otherwise return the relationship second[0] < first[0]
+func (m *OrderedFuncSet[T]) Less(sel MergeSelector) bool {
+	switch sel {
+	case First:
+		return m.less(m.first[m.posFirst], m.second[m.posSecond])
+	case Second:
+		return m.less(m.second[m.posSecond], m.first[m.posFirst])
+	}
+	panic("invalid selector")
+}
+
+// Append appends the first element of the selected array to the result
+func (m *OrderedFuncSet[T]) Append(sel MergeSelector) {
+	switch sel {
+	case First:
+		m.result = append(m.result, m.first[m.posFirst])
+	case Second:
+		m.result = append(m.result, m.second[m.posSecond])
+	}
+}
+
+// Len return length of first array if true, otherwise returns
+// length of second array
+func (m *OrderedFuncSet[T]) Len(sel MergeSelector) int {
+	switch sel {
+	case First:
+		return len(m.first) - m.posFirst
+	case Second:
+		return len(m.second) - m.posSecond
+	}
+	panic("invalid selector")
+}
+
+// Remove the first element of the selected array
+func (m *OrderedFuncSet[T]) Remove(sel MergeSelector) {
+	switch sel {
+	case First:
+		m.posFirst++
+	case Second:
+		m.posSecond++
+	default:
+		panic("unknown selector")
+
+	}
+}
+
+// Clear the result
+func (m *OrderedFuncSet[T]) Reset() {
+	m.result = nil
+	m.posFirst = 0
+	m.posSecond = 0
+}
+
+// OrderedSet allows for operations on sets of any ordered type
+type OrderedSet[T cmp.Ordered] struct {
+	OrderedFuncSet[T]
+}
+
+// NewOrderedSet merges a and b which must both be sorted in ascending order
+func NewOrderedSet[T cmp.Ordered](a, b []T) *OrderedSet[T] {
+	return &OrderedSet[T]{OrderedFuncSet[T]{first: a, second: b, less: cmp.Less[T]}}
+}
@@ -0,0 +1,536 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// arrayMax is the most values an array container holds, beyond it a
+	// bitmap container is smaller
+	arrayMax    = 4096
+	bitmapWords = (1 << 16) / 64
+	bitmapBytes = bitmapWords * 8
+	negativeMsg = "bitmap values must be non-negative"
+	unsortedMsg = "bitmap values must be sorted"
+)
+
+// container holds the low 16 bits of the values sharing the same high bits
+type container interface {
+	cardinality() int
+	contains(v uint16) bool
+	// add and remove may return a different kind of container
+	add(v uint16) container
+	remove(v uint16) container
+	// each yields the values in ascending order, false if yield stopped
+	each(yield func(uint16) bool) bool
+	// bitmap returns the container as a bitmap which must not be modified
+	bitmap() *bitmapContainer
+	clone() container
+}
+
+// arrayContainer is a sorted array, used for sparse chunks
+type arrayContainer struct {
+	vals []uint16
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.vals)
+}
+
+func (a *arrayContainer) contains(v uint16) bool {
+	_, found := slices.BinarySearch(a.vals, v)
+	return found
+}
+
+func (a *arrayContainer) add(v uint16) container {
+	pos, found := slices.BinarySearch(a.vals, v)
+	if found {
+		return a
+	}
+	if len(a.vals) == arrayMax {
+		return a.bitmap().add(v)
+	}
+	a.vals = slices.Insert(a.vals, pos, v)
+	return a
+}
+
+func (a *arrayContainer) remove(v uint16) container {
+	if pos, found := slices.BinarySearch(a.vals, v); found {
+		a.vals = slices.Delete(a.vals, pos, pos+1)
+	}
+	return a
+}
+
+func (a *arrayContainer) each(yield func(uint16) bool) bool {
+	for _, v := range a.vals {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) bitmap() *bitmapContainer {
+	b := new(bitmapContainer)
+	for _, v := range a.vals {
+		b.add(v)
+	}
+	return b
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{slices.Clone(a.vals)}
+}
+
+// bitmapContainer has one bit per possible value, used for dense chunks
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) contains(v uint16) bool {
+	return b.words[v/64]&(1<<(v%64)) != 0
+}
+
+func (b *bitmapContainer) add(v uint16) container {
+	if !b.contains(v) {
+		b.words[v/64] |= 1 << (v % 64)
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) remove(v uint16) container {
+	if !b.contains(v) {
+		return b
+	}
+	b.words[v/64] &^= 1 << (v % 64)
+	b.card--
+	if b.card <= arrayMax {
+		return toArray(b)
+	}
+	return b
+}
+
+func (b *bitmapContainer) each(yield func(uint16) bool) bool {
+	for i, w := range b.words {
+		for w != 0 {
+			if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) bitmap() *bitmapContainer {
+	return b
+}
+
+func (b *bitmapContainer) clone() container {
+	c := *b
+	return &c
+}
+
+// run is the inclusive range [start, last]
+type run struct {
+	start, last uint16
+}
+
+// runContainer is a sorted list of disjoint runs, used for chunks made
+// of long consecutive stretches
+type runContainer struct {
+	runs []run
+}
+
+func (r *runContainer) cardinality() int {
+	card := 0
+	for _, x := range r.runs {
+		card += int(x.last-x.start) + 1
+	}
+	return card
+}
+
+func (r *runContainer) contains(v uint16) bool {
+	i, _ := slices.BinarySearchFunc(r.runs, v, func(x run, v uint16) int {
+		if x.last < v {
+			return -1
+		}
+		return 1
+	})
+	return i < len(r.runs) && r.runs[i].start <= v
+}
+
+// expand converts r into an array or bitmap container so it can be modified
+func (r *runContainer) expand() container {
+	if r.cardinality() < arrayMax {
+		return toArray(r)
+	}
+	return r.bitmap()
+}
+
+func (r *runContainer) add(v uint16) container {
+	if r.contains(v) {
+		return r
+	}
+	return r.expand().add(v)
+}
+
+func (r *runContainer) remove(v uint16) container {
+	if !r.contains(v) {
+		return r
+	}
+	return r.expand().remove(v)
+}
+
+func (r *runContainer) each(yield func(uint16) bool) bool {
+	for _, x := range r.runs {
+		for v := int(x.start); v <= int(x.last); v++ {
+			if !yield(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) bitmap() *bitmapContainer {
+	b := new(bitmapContainer)
+	r.each(func(v uint16) bool {
+		b.add(v)
+		return true
+	})
+	return b
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{slices.Clone(r.runs)}
+}
+
+func toArray(c container) *arrayContainer {
+	a := &arrayContainer{make([]uint16, 0, c.cardinality())}
+	c.each(func(v uint16) bool {
+		a.vals = append(a.vals, v)
+		return true
+	})
+	return a
+}
+
+func toRuns(c container) *runContainer {
+	r := new(runContainer)
+	c.each(func(v uint16) bool {
+		if n := len(r.runs); n > 0 && int(r.runs[n-1].last)+1 == int(v) {
+			r.runs[n-1].last = v
+		} else {
+			r.runs = append(r.runs, run{v, v})
+		}
+		return true
+	})
+	return r
+}
+
+// optimize returns whichever kind of container stores c the smallest
+func optimize(c container) container {
+	card, runs := 0, 0
+	last := -2
+	c.each(func(v uint16) bool {
+		if int(v) != last+1 {
+			runs++
+		}
+		card, last = card+1, int(v)
+		return true
+	})
+
+	arrayCost, runCost := 2*card, 4*runs
+	switch {
+	case runCost < min(arrayCost, bitmapBytes):
+		if _, ok := c.(*runContainer); ok {
+			return c
+		}
+		return toRuns(c)
+	case card <= arrayMax:
+		if _, ok := c.(*arrayContainer); ok {
+			return c
+		}
+		return toArray(c)
+	}
+	return c.bitmap()
+}
+
+// wordwise combines a and b a word at a time
+func wordwise(a, b container, op func(x, y uint64) uint64) container {
+	x, y := a.bitmap(), b.bitmap()
+	result := new(bitmapContainer)
+	for i := range result.words {
+		result.words[i] = op(x.words[i], y.words[i])
+		result.card += bits.OnesCount64(result.words[i])
+	}
+	return optimize(result)
+}
+
+func andContainers(a, b container) container {
+	if _, ok := a.(*arrayContainer); !ok {
+		a, b = b, a
+	}
+	if x, ok := a.(*arrayContainer); ok {
+		result := new(arrayContainer)
+		for _, v := range x.vals {
+			if b.contains(v) {
+				result.vals = append(result.vals, v)
+			}
+		}
+		return result
+	}
+	return wordwise(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+// mergeArrays merges two sorted arrays, values found in both are kept
+// once if keepBoth and dropped otherwise
+func mergeArrays(a, b []uint16, keepBoth bool) []uint16 {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case b[j] < a[i]:
+			result = append(result, b[j])
+			j++
+		default:
+			if keepBoth {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+func orContainers(a, b container) container {
+	x, xOk := a.(*arrayContainer)
+	y, yOk := b.(*arrayContainer)
+	if xOk && yOk && len(x.vals)+len(y.vals) <= arrayMax {
+		return &arrayContainer{mergeArrays(x.vals, y.vals, true)}
+	}
+	return wordwise(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+func andNotContainers(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		result := new(arrayContainer)
+		for _, v := range x.vals {
+			if !b.contains(v) {
+				result.vals = append(result.vals, v)
+			}
+		}
+		return result
+	}
+	return wordwise(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+func xorContainers(a, b container) container {
+	x, xOk := a.(*arrayContainer)
+	y, yOk := b.(*arrayContainer)
+	if xOk && yOk && len(x.vals)+len(y.vals) <= arrayMax {
+		return &arrayContainer{mergeArrays(x.vals, y.vals, false)}
+	}
+	return wordwise(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// Bitmap is a compressed set of non-negative integers in the style of
+// Roaring bitmaps.  Values are split into chunks by their high bits and
+// each chunk picks an array, bitmap or run container depending on which
+// is smallest.  The zero value is an empty bitmap ready to use.
+type Bitmap struct {
+	keys       []uint64
+	containers []container
+}
+
+func split(v int) (uint64, uint16) {
+	if v < 0 {
+		panic(negativeMsg)
+	}
+	return uint64(v) >> 16, uint16(v)
+}
+
+// NewBitmap returns a bitmap containing vals
+func NewBitmap(vals ...int) *Bitmap {
+	b := new(Bitmap)
+	for _, v := range vals {
+		b.Add(v)
+	}
+	return b
+}
+
+// BitmapFromSorted builds a bitmap from ascending values, such as the
+// inputs given to NewOrderedIntSet
+func BitmapFromSorted(vals []int) *Bitmap {
+	b := new(Bitmap)
+	for i, v := range vals {
+		if i > 0 && v < vals[i-1] {
+			panic(unsortedMsg)
+		}
+		key, low := split(v)
+		if n := len(b.keys); n == 0 || b.keys[n-1] != key {
+			b.keys = append(b.keys, key)
+			b.containers = append(b.containers, new(arrayContainer))
+		}
+		last := len(b.containers) - 1
+		b.containers[last] = b.containers[last].add(low)
+	}
+	b.Optimize()
+	return b
+}
+
+// ToSlice returns the values in ascending order, the form used by
+// NewOrderedIntSet
+func (b *Bitmap) ToSlice() []int {
+	result := make([]int, 0, b.Cardinality())
+	for v := range b.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// All yields the values in ascending order
+func (b *Bitmap) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, c := range b.containers {
+			high := int(b.keys[i] << 16)
+			if !c.each(func(low uint16) bool { return yield(high | int(low)) }) {
+				return
+			}
+		}
+	}
+}
+
+// Add inserts v, returning false if it was already present
+func (b *Bitmap) Add(v int) bool {
+	key, low := split(v)
+	i, found := slices.BinarySearch(b.keys, key)
+	if !found {
+		b.keys = slices.Insert(b.keys, i, key)
+		b.containers = slices.Insert(b.containers, i, container(&arrayContainer{[]uint16{low}}))
+		return true
+	}
+	if b.containers[i].contains(low) {
+		return false
+	}
+	b.containers[i] = b.containers[i].add(low)
+	return true
+}
+
+// Remove deletes v, returning false if it was not present
+func (b *Bitmap) Remove(v int) bool {
+	if v < 0 {
+		return false
+	}
+	key, low := split(v)
+	i, found := slices.BinarySearch(b.keys, key)
+	if !found || !b.containers[i].contains(low) {
+		return false
+	}
+	b.containers[i] = b.containers[i].remove(low)
+	if b.containers[i].cardinality() == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+	return true
+}
+
+// Contains reports whether v is in the bitmap
+func (b *Bitmap) Contains(v int) bool {
+	if v < 0 {
+		return false
+	}
+	key, low := split(v)
+	i, found := slices.BinarySearch(b.keys, key)
+	return found && b.containers[i].contains(low)
+}
+
+// Cardinality returns the number of values in the bitmap
+func (b *Bitmap) Cardinality() int {
+	card := 0
+	for _, c := range b.containers {
+		card += c.cardinality()
+	}
+	return card
+}
+
+// Optimize converts every chunk to its smallest kind of container, which
+// is worth doing after many calls to Add or Remove
+func (b *Bitmap) Optimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// combine merges the chunks of b and o.  Chunks only in b are kept if
+// keepFirst, chunks only in o if keepSecond, and chunks in both are
+// combined by op.
+func (b *Bitmap) combine(o *Bitmap, op func(a, b container) container, keepFirst, keepSecond bool) *Bitmap {
+	result := new(Bitmap)
+	appendChunk := func(key uint64, c container) {
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(o.keys) {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			if keepFirst {
+				appendChunk(b.keys[i], b.containers[i].clone())
+			}
+			i++
+		case o.keys[j] < b.keys[i]:
+			if keepSecond {
+				appendChunk(o.keys[j], o.containers[j].clone())
+			}
+			j++
+		default:
+			appendChunk(b.keys[i], op(b.containers[i], o.containers[j]))
+			i++
+			j++
+		}
+	}
+	for ; keepFirst && i < len(b.keys); i++ {
+		appendChunk(b.keys[i], b.containers[i].clone())
+	}
+	for ; keepSecond && j < len(o.keys); j++ {
+		appendChunk(o.keys[j], o.containers[j].clone())
+	}
+	return result
+}
+
+// And returns a new bitmap with the values in both bitmaps
+func (b *Bitmap) And(o *Bitmap) *Bitmap {
+	return b.combine(o, andContainers, false, false)
+}
+
+// Or returns a new bitmap with the values in either bitmap
+func (b *Bitmap) Or(o *Bitmap) *Bitmap {
+	return b.combine(o, orContainers, true, true)
+}
+
+// AndNot returns a new bitmap with the values in b but not in o
+func (b *Bitmap) AndNot(o *Bitmap) *Bitmap {
+	return b.combine(o, andNotContainers, true, false)
+}
+
+// Xor returns a new bitmap with the values in exactly one of the bitmaps
+func (b *Bitmap) Xor(o *Bitmap) *Bitmap {
+	return b.combine(o, xorContainers, true, true)
+}
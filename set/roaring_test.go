@@ -0,0 +1,169 @@
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomBitmapValues mixes sparse, dense and consecutive chunks so every
+// kind of container is exercised
+func randomBitmapValues(r *rand.Rand) []int {
+	var vals []int
+	for chunk := 0; chunk < 6; chunk++ {
+		base := chunk << 16
+		switch r.Intn(3) {
+		case 0:
+			for i := 0; i < 100; i++ {
+				vals = append(vals, base+r.Intn(1<<16))
+			}
+		case 1:
+			for i := 0; i < 10000; i++ {
+				vals = append(vals, base+r.Intn(1<<16))
+			}
+		case 2:
+			start := r.Intn(1 << 15)
+			for i := start; i < start+r.Intn(20000); i++ {
+				vals = append(vals, base+i)
+			}
+		}
+	}
+	slices.Sort(vals)
+	return slices.Compact(vals)
+}
+
+func TestBitmapContainers(t *testing.T) {
+	sparse := BitmapFromSorted([]int{1, 5, 9})
+	dense := NewBitmap()
+	for i := 0; i < 1<<16; i += 2 {
+		dense.Add(i)
+	}
+	dense.Optimize()
+	var consecutive []int
+	for i := 100; i < 30000; i++ {
+		consecutive = append(consecutive, i)
+	}
+	runs := BitmapFromSorted(consecutive)
+
+	if _, ok := sparse.containers[0].(*arrayContainer); !ok {
+		t.Errorf("Expected an array container but found %T", sparse.containers[0])
+	}
+	if _, ok := dense.containers[0].(*bitmapContainer); !ok {
+		t.Errorf("Expected a bitmap container but found %T", dense.containers[0])
+	}
+	if _, ok := runs.containers[0].(*runContainer); !ok {
+		t.Errorf("Expected a run container but found %T", runs.containers[0])
+	}
+	if runs.Cardinality() != 29900 || !runs.Contains(100) || !runs.Contains(29999) || runs.Contains(30000) {
+		t.Error("Run container has the wrong contents")
+	}
+
+	// modifying a run container turns it back into something editable
+	if !runs.Remove(200) || runs.Contains(200) || runs.Cardinality() != 29899 {
+		t.Error("Expected Remove to work on a run container")
+	}
+}
+
+func TestBitmapAddRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	b := NewBitmap()
+	model := make(map[int]bool)
+
+	for i := 0; i < 20000; i++ {
+		v := r.Intn(3 << 16)
+		if r.Intn(3) == 0 {
+			if b.Remove(v) != model[v] {
+				t.Fatalf("Remove(%d) disagreed with the model", v)
+			}
+			delete(model, v)
+		} else {
+			if b.Add(v) == model[v] {
+				t.Fatalf("Add(%d) disagreed with the model", v)
+			}
+			model[v] = true
+		}
+	}
+	if b.Cardinality() != len(model) {
+		t.Fatalf("Expected %d values but found %d", len(model), b.Cardinality())
+	}
+	for v := range model {
+		if !b.Contains(v) {
+			t.Fatalf("Expected %d to be present", v)
+		}
+	}
+	if b.Contains(-1) || b.Remove(-1) {
+		t.Error("Negative values should never be present")
+	}
+}
+
+func TestBitmapOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for round := 0; round < 20; round++ {
+		a, c := randomBitmapValues(r), randomBitmapValues(r)
+		x, y := BitmapFromSorted(a), BitmapFromSorted(c)
+
+		if found := x.ToSlice(); !slices.Equal(found, a) {
+			t.Fatal("ToSlice did not round trip the sorted input")
+		}
+
+		tests := []struct {
+			op    string
+			found *Bitmap
+			merge func(OrderedSets)
+		}{
+			{"And", x.And(y), Intersect},
+			{"Or", x.Or(y), Union},
+			{"AndNot", x.AndNot(y), Subtract},
+			{"Xor", x.Xor(y), SymmetricDifference},
+		}
+		for _, test := range tests {
+			m := NewOrderedIntSet(a, c)
+			test.merge(m)
+			expected := m.GetResult()
+			if found := test.found.ToSlice(); !slices.Equal(found, expected) {
+				t.Fatalf("%s: Expected %d values Found: %d", test.op, len(expected), len(found))
+			}
+			if test.found.Cardinality() != len(expected) {
+				t.Fatalf("%s: Expected cardinality %d Found: %d", test.op, len(expected), test.found.Cardinality())
+			}
+		}
+	}
+
+	// results do not share containers with their inputs
+	x := BitmapFromSorted([]int{1, 2, 3})
+	y := x.Or(NewBitmap())
+	y.Add(4)
+	if x.Contains(4) {
+		t.Error("Modifying a result changed its input")
+	}
+}
+
+func TestBitmapFromSortedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected unsorted input to panic")
+		}
+	}()
+	BitmapFromSorted([]int{3, 1})
+}
+
+func BenchmarkBitmapArrayOr(b *testing.B) {
+	r := rand.New(rand.NewSource(19))
+	x, y := NewBitmap(), NewBitmap()
+	for x.Cardinality() < 2000 {
+		x.Add(r.Intn(1 << 16))
+	}
+	for y.Cardinality() < 2000 {
+		y.Add(r.Intn(1 << 16))
+	}
+	b.Run("Or", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Or(y)
+		}
+	})
+	b.Run("Xor", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Xor(y)
+		}
+	})
+}
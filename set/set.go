@@ -83,3 +83,30 @@ func Subtract(m OrderedSets) {
 		m.Remove(First)
 	}
 }
+
+// SymmetricDifference creates a new set containing everything in exactly one of the two sets
+func SymmetricDifference(m OrderedSets) {
+	m.Reset()
+	for m.Len(First) > 0 && m.Len(Second) > 0 {
+		if m.Less(First) {
+			m.Append(First)
+			m.Remove(First)
+		} else if m.Less(Second) {
+			m.Append(Second)
+			m.Remove(Second)
+		} else {
+			m.Remove(First)
+			m.Remove(Second)
+		}
+	}
+
+	for m.Len(First) > 0 {
+		m.Append(First)
+		m.Remove(First)
+	}
+
+	for m.Len(Second) > 0 {
+		m.Append(Second)
+		m.Remove(Second)
+	}
+}
@@ -1,6 +1,12 @@
 package set
 
-import "testing"
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
 
 func isSame(a ArrayCompare, N int) bool {
 	for i := 0; i < N; i++ {
@@ -150,3 +156,216 @@ func TestFloat64Sets(t *testing.T) {
 	testFloat64(t, second, third, secondThirdUnion, secondThirdIntersection, secondThirdSubtract)
 	testFloat64(t, third, second, secondThirdUnion, secondThirdIntersection, thirdSecondSubtract)
 }
+
+func testOrdered[T any](t *testing.T, m interface {
+	OrderedSets
+	GetResult() []T
+}, U, I, S []T, equal func(a, b T) bool) {
+	check := func(op string, expected []T) {
+		found := m.GetResult()
+		if !slices.EqualFunc(found, expected, equal) {
+			t.Errorf("%s: Expected: %v Found: %v", op, expected, found)
+		}
+	}
+
+	Union(m)
+	check("Union", U)
+	Intersect(m)
+	check("Intersect", I)
+	Subtract(m)
+	check("Subtract", S)
+}
+
+func TestOrderedSet(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	testOrdered(t, NewOrderedSet([]string{"a", "c", "d"}, []string{"b", "c", "e"}),
+		[]string{"a", "b", "c", "d", "e"}, []string{"c"}, []string{"a", "d"}, eq)
+	testOrdered(t, NewOrderedSet([]string{}, []string{"b"}),
+		[]string{"b"}, []string{}, []string{}, eq)
+
+	eq64 := func(a, b int64) bool { return a == b }
+	testOrdered(t, NewOrderedSet([]int64{-5, 1, 1 << 40}, []int64{1, 1 << 40, 1 << 41}),
+		[]int64{-5, 1, 1 << 40, 1 << 41}, []int64{1, 1 << 40}, []int64{-5}, eq64)
+}
+
+func TestOrderedFuncSet(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours ...int) []time.Time {
+		result := make([]time.Time, len(hours))
+		for i, h := range hours {
+			result[i] = base.Add(time.Duration(h) * time.Hour)
+		}
+		return result
+	}
+	before := func(a, b time.Time) bool { return a.Before(b) }
+	testOrdered(t, NewOrderedFuncSet(at(1, 2, 3), at(2, 4), before),
+		at(1, 2, 3, 4), at(2), at(1, 3), time.Time.Equal)
+
+	type point struct{ x, y int }
+	byXY := func(a, b point) bool { return a.x < b.x || a.x == b.x && a.y < b.y }
+	samePoint := func(a, b point) bool { return a == b }
+	testOrdered(t, NewOrderedFuncSet([]point{{0, 1}, {1, 0}}, []point{{0, 1}, {0, 2}}, byXY),
+		[]point{{0, 1}, {0, 2}, {1, 0}}, []point{{0, 1}}, []point{{1, 0}}, samePoint)
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	tests := []struct {
+		a, b, expected []int
+	}{
+		{[]int{1, 2, 3, 4}, []int{3, 4, 5}, []int{1, 2, 5}},
+		{[]int{1, 2}, []int{1, 2}, []int{}},
+		{[]int{}, []int{7, 8}, []int{7, 8}},
+		{[]int{1, 5, 9}, []int{2, 5, 10, 11}, []int{1, 2, 9, 10, 11}},
+	}
+	for _, test := range tests {
+		m := NewOrderedIntSet(test.a, test.b)
+		SymmetricDifference(m)
+		if found := m.GetResult(); !slices.Equal(found, test.expected) {
+			t.Errorf("SymmetricDifference(%v,%v) : Expected: %v Found: %v", test.a, test.b, test.expected, found)
+		}
+	}
+}
+
+// randomSets builds k sorted sets drawn from [0, max)
+func randomSets(r *rand.Rand, k, max int) [][]int {
+	lists := make([][]int, k)
+	for i := range lists {
+		for v := 0; v < max; v++ {
+			if r.Intn(3) > 0 {
+				lists[i] = append(lists[i], v)
+			}
+		}
+	}
+	return lists
+}
+
+func TestKWay(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for round := 0; round < 50; round++ {
+		lists := randomSets(r, r.Intn(6)+1, 30)
+
+		counts := make(map[int]int)
+		for _, l := range lists {
+			for _, v := range l {
+				counts[v]++
+			}
+		}
+		var union, intersection []int
+		for v := 0; v < 30; v++ {
+			if counts[v] > 0 {
+				union = append(union, v)
+			}
+			if counts[v] == len(lists) {
+				intersection = append(intersection, v)
+			}
+		}
+
+		if found := UnionAll(lists...); !slices.Equal(found, union) {
+			t.Fatalf("UnionAll(%v) : Expected: %v Found: %v", lists, union, found)
+		}
+		if found := IntersectAll(lists...); !slices.Equal(found, intersection) {
+			t.Fatalf("IntersectAll(%v) : Expected: %v Found: %v", lists, intersection, found)
+		}
+	}
+
+	if found := IntersectAll([]int{1, 2}, nil); len(found) != 0 {
+		t.Errorf("Expected an empty intersection but found %v", found)
+	}
+	if found := IntersectAll([]int{3}, []int{1, 3}, []int{3, 4}); !slices.Equal(found, []int{3}) {
+		t.Errorf("Expected [3] but found %v", found)
+	}
+	if found := UnionAll[int](); len(found) != 0 {
+		t.Errorf("Expected an empty union but found %v", found)
+	}
+}
+
+// postingLists builds k lists of n multiples of their index plus one, like
+// the posting lists of k words
+func postingLists(k, n int) [][]int {
+	lists := make([][]int, k)
+	for i := range lists {
+		lists[i] = make([]int, n)
+		for j := range lists[i] {
+			lists[i][j] = j * (i + 1)
+		}
+	}
+	return lists
+}
+
+func BenchmarkUnionAll(b *testing.B) {
+	lists := postingLists(32, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UnionAll(lists...)
+	}
+}
+
+func BenchmarkIntersectAll(b *testing.B) {
+	lists := postingLists(32, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		IntersectAll(lists...)
+	}
+}
+
+func TestAdaptiveIntersect(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for round := 0; round < 200; round++ {
+		lists := randomSets(r, 2, 200)
+		small := lists[0][:r.Intn(len(lists[0])/gallopRatio+1)]
+		for _, pair := range [][2][]int{{small, lists[1]}, {lists[1], small}, {lists[0], lists[1]}} {
+			expected := NewOrderedIntSet(pair[0], pair[1])
+			Intersect(expected)
+
+			found := NewOrderedIntSet(pair[0], pair[1])
+			AdaptiveIntersect(found)
+			if !slices.Equal(found.GetResult(), expected.GetResult()) {
+				t.Fatalf("AdaptiveIntersect(%v,%v) : Expected: %v Found: %v", pair[0], pair[1], expected.GetResult(), found.GetResult())
+			}
+		}
+	}
+
+	m := NewOrderedIntSet([]int{2, 3, 100}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 100})
+	AdaptiveIntersect(m)
+	if found := m.GetResult(); !slices.Equal(found, []int{2, 3, 100}) {
+		t.Errorf("Expected [2 3 100] but found %v", found)
+	}
+}
+
+// BenchmarkIntersectCrossover compares the linear merge with galloping as
+// the larger input grows relative to the smaller one
+func BenchmarkIntersectCrossover(b *testing.B) {
+	const large = 1 << 16
+	big := make([]int, large)
+	for i := range big {
+		big[i] = 2 * i
+	}
+	for _, ratio := range []int{1, 2, 4, 8, 16, 32, 128, 1024} {
+		small := make([]int, large/ratio)
+		for i := range small {
+			small[i] = i * ratio * 2
+		}
+		b.Run(fmt.Sprintf("Intersect/ratio=%d", ratio), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Intersect(NewOrderedIntSet(small, big))
+			}
+		})
+		b.Run(fmt.Sprintf("merge/ratio=%d", ratio), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				merge(small, big, nil)
+			}
+		})
+		b.Run(fmt.Sprintf("gallop/ratio=%d", ratio), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gallop(small, big, nil)
+			}
+		})
+		b.Run(fmt.Sprintf("AdaptiveIntersect/ratio=%d", ratio), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				AdaptiveIntersect(NewOrderedIntSet(small, big))
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+)
+
+type avlNode[T any] struct {
+	value       T
+	left, right *avlNode[T]
+	height      int
+	size        int
+}
+
+func (n *avlNode[T]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *avlNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *avlNode[T]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *avlNode[T]) rotateRight() *avlNode[T] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *avlNode[T]) rotateLeft() *avlNode[T] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// balance restores the AVL property at n after one of its subtrees changed
+// height by at most one
+func (n *avlNode[T]) balance() *avlNode[T] {
+	n.update()
+	switch diff := n.left.getHeight() - n.right.getHeight(); {
+	case diff > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case diff < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// SortedSet keeps its values ordered under insertion and removal using an
+// AVL tree where every node knows the size of its subtree.  The zero value
+// is not usable, construct one with NewSortedSet or NewSortedSetFunc.
+type SortedSet[T any] struct {
+	root *avlNode[T]
+	less func(a, b T) bool
+}
+
+// NewSortedSet returns a set of ordered values containing vals
+func NewSortedSet[T cmp.Ordered](vals ...T) *SortedSet[T] {
+	return NewSortedSetFunc(cmp.Less[T], vals...)
+}
+
+// NewSortedSetFunc returns a set ordered by less containing vals
+func NewSortedSetFunc[T any](less func(a, b T) bool, vals ...T) *SortedSet[T] {
+	s := &SortedSet[T]{less: less}
+	for _, v := range vals {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *SortedSet[T]) add(n *avlNode[T], v T) (*avlNode[T], bool) {
+	if n == nil {
+		return &avlNode[T]{value: v, height: 1, size: 1}, true
+	}
+	var added bool
+	switch {
+	case s.less(v, n.value):
+		n.left, added = s.add(n.left, v)
+	case s.less(n.value, v):
+		n.right, added = s.add(n.right, v)
+	default:
+		return n, false
+	}
+	return n.balance(), added
+}
+
+// Add inserts v, returning false if it was already present
+func (s *SortedSet[T]) Add(v T) bool {
+	var added bool
+	s.root, added = s.add(s.root, v)
+	return added
+}
+
+// removeMin detaches the least node below n, returning the new subtree
+// and the detached node
+func (s *SortedSet[T]) removeMin(n *avlNode[T]) (*avlNode[T], *avlNode[T]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var least *avlNode[T]
+	n.left, least = s.removeMin(n.left)
+	return n.balance(), least
+}
+
+func (s *SortedSet[T]) remove(n *avlNode[T], v T) (*avlNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch {
+	case s.less(v, n.value):
+		n.left, removed = s.remove(n.left, v)
+	case s.less(n.value, v):
+		n.right, removed = s.remove(n.right, v)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// replace n by its successor
+		right, successor := s.removeMin(n.right)
+		successor.left, successor.right = n.left, right
+		return successor.balance(), true
+	}
+	return n.balance(), removed
+}
+
+// Remove deletes v, returning false if it was not present
+func (s *SortedSet[T]) Remove(v T) bool {
+	var removed bool
+	s.root, removed = s.remove(s.root, v)
+	return removed
+}
+
+// Contains reports whether v is in the set
+func (s *SortedSet[T]) Contains(v T) bool {
+	for n := s.root; n != nil; {
+		switch {
+		case s.less(v, n.value):
+			n = n.left
+		case s.less(n.value, v):
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of values in the set
+func (s *SortedSet[T]) Len() int {
+	return s.root.getSize()
+}
+
+// Min returns the least value, false if the set is empty
+func (s *SortedSet[T]) Min() (T, bool) {
+	return s.Select(0)
+}
+
+// Max returns the greatest value, false if the set is empty
+func (s *SortedSet[T]) Max() (T, bool) {
+	return s.Select(s.Len() - 1)
+}
+
+// Floor returns the greatest value less than or equal to v, false if
+// there is none
+func (s *SortedSet[T]) Floor(v T) (T, bool) {
+	var result T
+	found := false
+	for n := s.root; n != nil; {
+		if s.less(v, n.value) {
+			n = n.left
+		} else {
+			result, found = n.value, true
+			n = n.right
+		}
+	}
+	return result, found
+}
+
+// Ceiling returns the least value greater than or equal to v, false if
+// there is none
+func (s *SortedSet[T]) Ceiling(v T) (T, bool) {
+	var result T
+	found := false
+	for n := s.root; n != nil; {
+		if s.less(n.value, v) {
+			n = n.right
+		} else {
+			result, found = n.value, true
+			n = n.left
+		}
+	}
+	return result, found
+}
+
+// Rank returns the number of values less than v
+func (s *SortedSet[T]) Rank(v T) int {
+	rank := 0
+	for n := s.root; n != nil; {
+		if s.less(n.value, v) {
+			rank += n.left.getSize() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
+
+// Select returns the value with rank i, false if i is out of range
+func (s *SortedSet[T]) Select(i int) (T, bool) {
+	for n := s.root; n != nil; {
+		switch left := n.left.getSize(); {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// walk yields the values below n within [lo, hi), a nil bound is open
+func (s *SortedSet[T]) walk(n *avlNode[T], lo, hi *T, yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || !s.less(n.value, *lo)
+	belowHi := hi == nil || s.less(n.value, *hi)
+	if aboveLo && !s.walk(n.left, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(n.value) {
+		return false
+	}
+	if belowHi {
+		return s.walk(n.right, lo, hi, yield)
+	}
+	return true
+}
+
+// All yields every value in ascending order
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.walk(s.root, nil, nil, yield)
+	}
+}
+
+// Range yields the values v with lo <= v < hi in ascending order
+func (s *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.walk(s.root, &lo, &hi, yield)
+	}
+}
@@ -0,0 +1,119 @@
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkAVL verifies the balance, height and size of every node below n
+func checkAVL(t *testing.T, n *avlNode[int]) {
+	if n == nil {
+		return
+	}
+	checkAVL(t, n.left)
+	checkAVL(t, n.right)
+	if diff := n.left.getHeight() - n.right.getHeight(); diff < -1 || diff > 1 {
+		t.Fatalf("Node %d is unbalanced by %d", n.value, diff)
+	}
+	if n.height != 1+max(n.left.getHeight(), n.right.getHeight()) || n.size != 1+n.left.getSize()+n.right.getSize() {
+		t.Fatalf("Node %d has stale height or size", n.value)
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	s := NewSortedSet[int]()
+	var model []int
+
+	if _, ok := s.Min(); ok {
+		t.Error("Expected Min to fail on an empty set")
+	}
+
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(200)
+		pos, exists := slices.BinarySearch(model, v)
+		if r.Intn(3) == 0 {
+			if s.Remove(v) != exists {
+				t.Fatalf("Remove(%d) disagreed with the model", v)
+			}
+			if exists {
+				model = slices.Delete(model, pos, pos+1)
+			}
+		} else {
+			if s.Add(v) == exists {
+				t.Fatalf("Add(%d) disagreed with the model", v)
+			}
+			if !exists {
+				model = slices.Insert(model, pos, v)
+			}
+		}
+		checkAVL(t, s.root)
+	}
+
+	if s.Len() != len(model) {
+		t.Fatalf("Expected %d values but found %d", len(model), s.Len())
+	}
+	if found := slices.Collect(s.All()); !slices.Equal(found, model) {
+		t.Fatalf("Expected %v but found %v", model, found)
+	}
+	if min, _ := s.Min(); min != model[0] {
+		t.Errorf("Expected min of %d but found %d", model[0], min)
+	}
+	if max, _ := s.Max(); max != model[len(model)-1] {
+		t.Errorf("Expected max of %d but found %d", model[len(model)-1], max)
+	}
+
+	for v := -1; v <= 201; v++ {
+		pos, exists := slices.BinarySearch(model, v)
+		if s.Contains(v) != exists {
+			t.Fatalf("Contains(%d) disagreed with the model", v)
+		}
+		if s.Rank(v) != pos {
+			t.Fatalf("Rank(%d): Expected %d Found: %d", v, pos, s.Rank(v))
+		}
+
+		floor, ok := s.Floor(v)
+		if expected := pos - 1; exists {
+			if !ok || floor != v {
+				t.Fatalf("Floor(%d): Expected %d Found: %d", v, v, floor)
+			}
+		} else if ok != (expected >= 0) || ok && floor != model[expected] {
+			t.Fatalf("Floor(%d) disagreed with the model", v)
+		}
+
+		ceiling, ok := s.Ceiling(v)
+		if ok != (pos < len(model)) || ok && ceiling != model[pos] {
+			t.Fatalf("Ceiling(%d) disagreed with the model", v)
+		}
+	}
+
+	for i, v := range model {
+		if found, ok := s.Select(i); !ok || found != v {
+			t.Fatalf("Select(%d): Expected %d Found: %d", i, v, found)
+		}
+	}
+	if _, ok := s.Select(len(model)); ok {
+		t.Error("Expected Select to fail out of range")
+	}
+
+	lo, hi := 50, 120
+	expected := model[s.Rank(lo):s.Rank(hi)]
+	if found := slices.Collect(s.Range(lo, hi)); !slices.Equal(found, expected) {
+		t.Errorf("Range(%d,%d): Expected %v Found: %v", lo, hi, expected, found)
+	}
+	for v := range s.Range(lo, hi) {
+		if v != expected[0] {
+			t.Errorf("Expected Range to start at %d but found %d", expected[0], v)
+		}
+		break
+	}
+}
+
+func TestSortedSetFunc(t *testing.T) {
+	s := NewSortedSetFunc(func(a, b string) bool { return len(a) < len(b) || len(a) == len(b) && a < b },
+		"ccc", "a", "bb", "aa")
+	if found := slices.Collect(s.All()); !slices.Equal(found, []string{"a", "aa", "bb", "ccc"}) {
+		t.Errorf("Expected [a aa bb ccc] but found %v", found)
+	}
+}
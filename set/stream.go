@@ -0,0 +1,83 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+)
+
+// mergeSeq walks two sorted sequences in step, yielding the elements found
+// only in a if keepFirst, in both if keepBoth and only in b if keepSecond.
+// Neither input is read further than needed.
+func mergeSeq[T any](a, b iter.Seq[T], less func(x, y T) bool, keepFirst, keepBoth, keepSecond bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextFirst, stopFirst := iter.Pull(a)
+		defer stopFirst()
+		nextSecond, stopSecond := iter.Pull(b)
+		defer stopSecond()
+
+		x, okFirst := nextFirst()
+		y, okSecond := nextSecond()
+		for okFirst && okSecond {
+			switch {
+			case less(x, y):
+				if keepFirst && !yield(x) {
+					return
+				}
+				x, okFirst = nextFirst()
+			case less(y, x):
+				if keepSecond && !yield(y) {
+					return
+				}
+				y, okSecond = nextSecond()
+			default:
+				if keepBoth && !yield(y) {
+					return
+				}
+				x, okFirst = nextFirst()
+				y, okSecond = nextSecond()
+			}
+		}
+
+		for ; keepFirst && okFirst; x, okFirst = nextFirst() {
+			if !yield(x) {
+				return
+			}
+		}
+		for ; keepSecond && okSecond; y, okSecond = nextSecond() {
+			if !yield(y) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectSeqFunc lazily intersects two sequences sorted by less
+func IntersectSeqFunc[T any](a, b iter.Seq[T], less func(x, y T) bool) iter.Seq[T] {
+	return mergeSeq(a, b, less, false, true, false)
+}
+
+// UnionSeqFunc lazily unions two sequences sorted by less
+func UnionSeqFunc[T any](a, b iter.Seq[T], less func(x, y T) bool) iter.Seq[T] {
+	return mergeSeq(a, b, less, true, true, true)
+}
+
+// SubtractSeqFunc lazily yields everything in a but not in b, both sorted by less
+func SubtractSeqFunc[T any](a, b iter.Seq[T], less func(x, y T) bool) iter.Seq[T] {
+	return mergeSeq(a, b, less, true, false, false)
+}
+
+// IntersectSeq lazily intersects two sequences sorted in ascending order
+func IntersectSeq[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return IntersectSeqFunc(a, b, cmp.Less[T])
+}
+
+// UnionSeq lazily unions two sequences sorted in ascending order
+func UnionSeq[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return UnionSeqFunc(a, b, cmp.Less[T])
+}
+
+// SubtractSeq lazily yields everything in a but not in b, both sorted in
+// ascending order
+func SubtractSeq[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return SubtractSeqFunc(a, b, cmp.Less[T])
+}
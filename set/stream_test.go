@@ -0,0 +1,71 @@
+package set
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// multiples yields every multiple of n forever
+func multiples(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; yield(i * n); i++ {
+		}
+	}
+}
+
+func TestStreamSets(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	for round := 0; round < 50; round++ {
+		lists := randomSets(r, 2, 40)
+		a, b := lists[0], lists[1]
+
+		tests := []struct {
+			op    string
+			found iter.Seq[int]
+			merge func(OrderedSets)
+		}{
+			{"IntersectSeq", IntersectSeq(slices.Values(a), slices.Values(b)), Intersect},
+			{"UnionSeq", UnionSeq(slices.Values(a), slices.Values(b)), Union},
+			{"SubtractSeq", SubtractSeq(slices.Values(a), slices.Values(b)), Subtract},
+		}
+		for _, test := range tests {
+			m := NewOrderedIntSet(a, b)
+			test.merge(m)
+			if found := slices.Collect(test.found); !slices.Equal(found, m.GetResult()) {
+				t.Fatalf("%s(%v,%v) : Expected: %v Found: %v", test.op, a, b, m.GetResult(), found)
+			}
+		}
+	}
+}
+
+func TestStreamSetsAreLazy(t *testing.T) {
+	var found []int
+	for v := range IntersectSeq(multiples(2), multiples(3)) {
+		if v > 30 {
+			break
+		}
+		found = append(found, v)
+	}
+	if expected := []int{0, 6, 12, 18, 24, 30}; !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+
+	found = found[:0]
+	for v := range SubtractSeq(multiples(1), multiples(2)) {
+		if len(found) == 3 {
+			break
+		}
+		found = append(found, v)
+	}
+	if expected := []int{1, 3, 5}; !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+
+	words := UnionSeqFunc(slices.Values([]string{"b", "aa"}), slices.Values([]string{"c", "aa", "ddd"}),
+		func(x, y string) bool { return len(x) < len(y) || len(x) == len(y) && x < y })
+	if found := slices.Collect(words); !slices.Equal(found, []string{"b", "c", "aa", "ddd"}) {
+		t.Errorf("Expected [b c aa ddd] but found %v", found)
+	}
+}
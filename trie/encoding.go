@@ -0,0 +1,335 @@
+package trie
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	trieFormat      = 't'
+	radixFormat     = 'r'
+	encodingVersion = 1
+
+	wordFlag = 1
+)
+
+var (
+	// ErrBadHeader is returned when decoding data which is not a binary
+	// encoding of the same kind of trie and version
+	ErrBadHeader = errors.New("not a trie encoding of this kind or version")
+	// ErrTruncated is returned when decoding data which ends too early
+	ErrTruncated = errors.New("trie encoding is truncated")
+	// ErrCorrupt is returned when decoding data no trie encodes to
+	ErrCorrupt = errors.New("trie encoding is corrupt")
+	// ErrTrailing is returned when decoding data with bytes left over
+	ErrTrailing = errors.New("unexpected data after trie encoding")
+	// ErrInvalidUTF8 is returned when encoding a word which is not valid
+	// UTF-8 as JSON, which would replace its invalid bytes
+	ErrInvalidUTF8 = errors.New("trie word is not valid UTF-8")
+)
+
+// decoder reads the binary encoding, remembering the first error
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) header(format byte) {
+	if len(d.data) < 2 || d.data[0] != format || d.data[1] != encodingVersion {
+		d.fail(ErrBadHeader)
+		return
+	}
+	d.data = d.data[2:]
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) == 0 {
+		d.fail(ErrTruncated)
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+// flags reads the flags of a node, reporting whether it holds a word
+func (d *decoder) flags() bool {
+	switch d.byte() {
+	case 0:
+		return false
+	case wordFlag:
+		return true
+	}
+	d.fail(ErrCorrupt)
+	return false
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail(ErrTruncated)
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// count reads a number of entries, each of which takes at least one byte
+func (d *decoder) count() int {
+	n := d.uvarint()
+	if n > uint64(len(d.data)) {
+		d.fail(ErrCorrupt)
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
+
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.data) != 0 {
+		d.fail(ErrTrailing)
+	}
+	return d.err
+}
+
+// extend appends label to a path, reporting false if that changes how
+// decodeKey reads the path, which Insert never allows.  Only the keys in
+// the last few bytes can change, tail holds the path from the first of
+// those and the result is the tail of the extended path.
+func extend(tail, label string) (string, bool) {
+	s := tail + label
+	ok, start := tail == "", len(s)
+	for i := 0; i < len(s); {
+		if i == len(tail) {
+			ok = true
+		}
+		if start == len(s) && i+utf8.UTFMax > len(s) {
+			start = i
+		}
+		_, width := decodeKey(s[i:])
+		i += width
+	}
+	return s[start:], ok
+}
+
+func flags(word bool) byte {
+	if word {
+		return wordFlag
+	}
+	return 0
+}
+
+// Each node is encoded in pre-order as its flags, the number of children
+// and then every child in order, preceded by its rune
+
+func encodeTrie(n *node[struct{}], buf []byte) []byte {
+	buf = append(buf, flags(n.set))
+	buf = binary.AppendUvarint(buf, uint64(len(n.children)))
+	for _, r := range n.keys() {
+		buf = binary.AppendUvarint(buf, uint64(r))
+		buf = encodeTrie(n.children[r], buf)
+	}
+	return buf
+}
+
+// trie decodes a node whose path ends with tail as returned by extend
+func (d *decoder) trie(tail string) *node[struct{}] {
+	n := newNode[struct{}]()
+	n.set = d.flags()
+	if n.set {
+		n.size = 1
+	}
+	for i, count := 0, d.count(); i < count && d.err == nil; i++ {
+		v := d.uvarint()
+		r := rune(v)
+		// every rune must be one decodeKey can produce
+		if v > maxKey {
+			d.fail(ErrCorrupt)
+		} else if k, _ := decodeKey(string(appendKey(nil, r))); k != r {
+			d.fail(ErrCorrupt)
+		}
+		if _, exists := n.children[r]; exists {
+			d.fail(ErrCorrupt)
+		}
+		next, ok := extend(tail, string(appendKey(nil, r)))
+		if !ok {
+			d.fail(ErrCorrupt)
+		}
+		c := d.trie(next)
+		if c.size == 0 {
+			d.fail(ErrCorrupt)
+		}
+		n.children[r] = c
+		n.size += c.size
+	}
+	return n
+}
+
+func (t *trie) MarshalBinary() ([]byte, error) {
+	return encodeTrie(t.root, []byte{trieFormat, encodingVersion}), nil
+}
+
+func (t *trie) UnmarshalBinary(data []byte) error {
+	d := &decoder{data: data}
+	d.header(trieFormat)
+	n := d.trie("")
+	if err := d.finish(); err != nil {
+		return err
+	}
+	t.root = n
+	return nil
+}
+
+// marshalWords encodes words as a JSON array
+func marshalWords(words []string) ([]byte, error) {
+	for _, w := range words {
+		if !utf8.ValidString(w) {
+			return nil, ErrInvalidUTF8
+		}
+	}
+	return json.Marshal(append([]string{}, words...))
+}
+
+func (t *trie) MarshalJSON() ([]byte, error) {
+	return marshalWords(t.WordsWithPrefix("", 0))
+}
+
+func (t *trie) UnmarshalJSON(data []byte) error {
+	var words []string
+	if err := json.Unmarshal(data, &words); err != nil {
+		return err
+	}
+	n := newNode[struct{}]()
+	for _, w := range words {
+		n.put(w, struct{}{})
+	}
+	t.root = n
+	return nil
+}
+
+// Radix nodes are encoded the same way, with the children preceded by
+// their labels instead of a rune
+
+func (t *radix) encode(buf []byte) []byte {
+	buf = append(buf, flags(t.word))
+	buf = binary.AppendUvarint(buf, uint64(len(t.children)))
+	for _, c := range t.children {
+		buf = binary.AppendUvarint(buf, uint64(len(c.label)))
+		buf = append(buf, c.label...)
+		buf = c.encode(buf)
+	}
+	return buf
+}
+
+// radix decodes a node whose parent's path ends with tail as returned by
+// extend
+func (d *decoder) radix(tail, label string) *radix {
+	n := &radix{label: label}
+	tail, ok := extend(tail, label)
+	if !ok {
+		d.fail(ErrCorrupt)
+	}
+	n.word = d.flags()
+	if n.word {
+		n.size = 1
+	}
+	count := d.count()
+	n.children = make([]*radix, 0, count)
+	for i := 0; i < count && d.err == nil; i++ {
+		c := d.radix(tail, d.string())
+		if c.label == "" || c.size == 0 {
+			d.fail(ErrCorrupt)
+		}
+		if i > 0 && compareKeys(leading(n.children[i-1].label), leading(c.label)) >= 0 {
+			d.fail(ErrCorrupt)
+		}
+		n.children = append(n.children, c)
+		n.size += c.size
+	}
+	// Insert and Remove never leave a chain which merge would collapse
+	if label != "" && !n.word && len(n.children) == 1 {
+		d.fail(ErrCorrupt)
+	}
+	return n
+}
+
+func (t *radix) MarshalBinary() ([]byte, error) {
+	return t.encode([]byte{radixFormat, encodingVersion}), nil
+}
+
+func (t *radix) UnmarshalBinary(data []byte) error {
+	d := &decoder{data: data}
+	d.header(radixFormat)
+	n := d.radix("", "")
+	if err := d.finish(); err != nil {
+		return err
+	}
+	*t = *n
+	return nil
+}
+
+func (t *radix) MarshalJSON() ([]byte, error) {
+	return marshalWords(t.WordsWithPrefix("", 0))
+}
+
+func (t *radix) UnmarshalJSON(data []byte) error {
+	var words []string
+	if err := json.Unmarshal(data, &words); err != nil {
+		return err
+	}
+	n := &radix{}
+	for _, w := range words {
+		n.Insert(w)
+	}
+	*t = *n
+	return nil
+}
+
+// A Map is encoded as a JSON object from key to value
+
+func (m *trieMap[V]) MarshalJSON() ([]byte, error) {
+	pairs := make(map[string]V, m.Count())
+	for k, v := range m.All() {
+		if !utf8.ValidString(k) {
+			return nil, ErrInvalidUTF8
+		}
+		pairs[k] = v
+	}
+	return json.Marshal(pairs)
+}
+
+func (m *trieMap[V]) UnmarshalJSON(data []byte) error {
+	var pairs map[string]V
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return err
+	}
+	n := newNode[V]()
+	for k, v := range pairs {
+		n.put(k, v)
+	}
+	m.root = n
+	return nil
+}
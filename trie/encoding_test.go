@@ -0,0 +1,143 @@
+package trie
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTrieEncoding(t *testing.T) {
+	words := randomWords(500, 3)
+	for name, newTrie := range map[string]func() Trie{"trie": New, "radix": NewRadix} {
+		tr := newTrie()
+		for _, w := range words {
+			tr.Insert(w)
+		}
+		expected := tr.WordsWithPrefix("", 0)
+
+		bin, err := tr.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		binCopy := newTrie()
+		binCopy.Insert("stale")
+		if err := binCopy.UnmarshalBinary(bin); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		data, err := json.Marshal(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jsonCopy := newTrie()
+		if err := json.Unmarshal(data, jsonCopy); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		for _, c := range []Trie{binCopy, jsonCopy} {
+			if c.Count() != tr.Count() {
+				t.Errorf("%s: Expected %d words but found %d", name, tr.Count(), c.Count())
+			}
+			if found := c.WordsWithPrefix("", 0); !slices.Equal(found, expected) {
+				t.Errorf("%s: Expected %v Found: %v", name, expected, found)
+			}
+		}
+
+		for i := 0; i < len(bin); i++ {
+			if err := newTrie().UnmarshalBinary(bin[:i]); err == nil {
+				t.Errorf("%s: Expected an error decoding %d truncated bytes", name, i)
+			}
+		}
+		if err := newTrie().UnmarshalBinary(append(bin, 0)); !errors.Is(err, ErrTrailing) {
+			t.Errorf("%s: Expected ErrTrailing decoding trailing bytes but found %v", name, err)
+		}
+	}
+
+	if err := New().UnmarshalBinary([]byte{radixFormat, encodingVersion, 0, 0}); !errors.Is(err, ErrBadHeader) {
+		t.Errorf("Expected ErrBadHeader decoding a radix encoding into a trie but found %v", err)
+	}
+	if data, _ := json.Marshal(NewRadix()); string(data) != "[]" {
+		t.Errorf("Expected [] but found %s", data)
+	}
+}
+
+func TestTrieDecodeCorrupt(t *testing.T) {
+	// "\xc3" then "\xa9" as two invalid bytes, together they decode as é
+	split := []byte{trieFormat, encodingVersion, 0, 1}
+	split = binary.AppendUvarint(split, uint64(utf8.MaxRune+1+0xc3))
+	split = append(split, 0, 1)
+	split = binary.AppendUvarint(split, uint64(utf8.MaxRune+1+0xa9))
+	split = append(split, wordFlag, 0)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+		tr   Trie
+	}{
+		{"unknown flag", []byte{trieFormat, encodingVersion, 7, 0}, ErrCorrupt, New()},
+		{"unknown radix flag", []byte{radixFormat, encodingVersion, 7, 0}, ErrCorrupt, NewRadix()},
+		{"empty branch", []byte{trieFormat, encodingVersion, 0, 1, 'a', 0, 0}, ErrCorrupt, New()},
+		{"duplicate rune", []byte{trieFormat, encodingVersion, 0, 2, 'a', 1, 0, 'a', 1, 0}, ErrCorrupt, New()},
+		{"uncompressed chain", []byte{radixFormat, encodingVersion, 0, 1, 1, 'a', 0, 1, 1, 'b', 1, 0}, ErrCorrupt, NewRadix()},
+		{"unsorted children", []byte{radixFormat, encodingVersion, 0, 2, 1, 'b', 1, 0, 1, 'a', 1, 0}, ErrCorrupt, NewRadix()},
+		{"empty label", []byte{radixFormat, encodingVersion, 0, 1, 0, 1, 0}, ErrCorrupt, NewRadix()},
+		{"split rune", split, ErrCorrupt, New()},
+		{"label splits rune", []byte{radixFormat, encodingVersion, 0, 1, 1, 0xc3, 0, 2, 1, 0xa8, 1, 0, 1, 0xa9, 1, 0}, ErrCorrupt, NewRadix()},
+		{"labels split rune", []byte{radixFormat, encodingVersion, 0, 1, 1, 0xe2, 1, 1, 1, 0x82, 1, 1, 1, 0xac, 1, 0}, ErrCorrupt, NewRadix()},
+		{"truncated", []byte{trieFormat, encodingVersion, 0}, ErrTruncated, New()},
+		{"bad version", []byte{trieFormat, encodingVersion + 1, 0, 0}, ErrBadHeader, New()},
+	}
+	for _, test := range tests {
+		if err := test.tr.UnmarshalBinary(test.data); !errors.Is(err, test.err) {
+			t.Errorf("%s: Expected %v Found: %v", test.name, test.err, err)
+		}
+	}
+
+	// a non-word root with one child is how a single word is encoded
+	tr := NewRadix()
+	if err := tr.UnmarshalBinary([]byte{radixFormat, encodingVersion, 0, 1, 1, 'a', 1, 0}); err != nil || !tr.Contains("a") {
+		t.Errorf("Expected to decode a single word but found %v", err)
+	}
+}
+
+func TestMapEncoding(t *testing.T) {
+	m := NewMap[int]()
+	for i, w := range words {
+		m.Put(w, i)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewMap[int]()
+	c.Put("stale", 1)
+	if err := json.Unmarshal(data, c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Count() != m.Count() {
+		t.Errorf("Expected %d keys but found %d", m.Count(), c.Count())
+	}
+	for k, v := range m.All() {
+		if found, ok := c.Get(k); !ok || found != v {
+			t.Errorf("Get(%q): Expected (%d,true) Found: (%d,%v)", k, v, found, ok)
+		}
+	}
+
+	if data, _ := json.Marshal(NewMap[int]()); string(data) != "{}" {
+		t.Errorf("Expected {} but found %s", data)
+	}
+	invalid := NewMap[int]()
+	invalid.Put("\xff", 1)
+	invalid.Put("\xfe", 2)
+	if _, err := json.Marshal(invalid); !errors.Is(err, ErrInvalidUTF8) {
+		t.Errorf("Expected ErrInvalidUTF8 encoding invalid keys but found %v", err)
+	}
+	if err := json.Unmarshal([]byte(`["a"]`), NewMap[int]()); err == nil {
+		t.Error("Expected an error decoding an array into a map")
+	}
+}
@@ -0,0 +1,78 @@
+package trie
+
+import "slices"
+
+// nextRow computes the Levenshtein row for the path extended by r from the
+// row of its parent path
+func nextRow(prev []int, query []rune, r rune) []int {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if query[i-1] == r {
+			cost = 0
+		}
+		row[i] = min(row[i-1]+1, prev[i]+1, prev[i-1]+cost)
+	}
+	return row
+}
+
+// firstRow is the Levenshtein row of the empty path
+func firstRow(query []rune) []int {
+	row := make([]int, len(query)+1)
+	for i := range row {
+		row[i] = i
+	}
+	return row
+}
+
+func (n *node[V]) fuzzy(buf []byte, query []rune, row []int, k int, result []string) []string {
+	if n.set && row[len(query)] <= k {
+		result = append(result, string(buf))
+	}
+	// every entry of a row only grows deeper down, so stop once all exceed k
+	if slices.Min(row) > k {
+		return result
+	}
+	for _, r := range n.keys() {
+		result = n.children[r].fuzzy(appendKey(buf, r), query, nextRow(row, query, r), k, result)
+	}
+	return result
+}
+
+func (t *trie) Fuzzy(query string, k int) []string {
+	if k < 0 {
+		return nil
+	}
+	q := slices.Collect(keyRunes(query))
+	return t.root.fuzzy(nil, q, firstRow(q), k, nil)
+}
+
+func (t *radix) fuzzy(buf []byte, query []rune, row []int, k int, result []string) []string {
+	if t.word && row[len(query)] <= k {
+		result = append(result, string(buf))
+	}
+	if slices.Min(row) > k {
+		return result
+	}
+	for _, c := range t.children {
+		next := row
+		for r := range keyRunes(c.label) {
+			if next = nextRow(next, query, r); slices.Min(next) > k {
+				break
+			}
+		}
+		if slices.Min(next) <= k {
+			result = c.fuzzy(append(buf, c.label...), query, next, k, result)
+		}
+	}
+	return result
+}
+
+func (t *radix) Fuzzy(query string, k int) []string {
+	if k < 0 {
+		return nil
+	}
+	q := slices.Collect(keyRunes(query))
+	return t.fuzzy(nil, q, firstRow(q), k, nil)
+}
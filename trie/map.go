@@ -0,0 +1,69 @@
+package trie
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+// Map is a trie which associates a value with every stored key, keeping
+// the keys in the same order as Trie.  It encodes to JSON as an object,
+// failing with ErrInvalidUTF8 if a key is not valid UTF-8.  There is no
+// binary encoding since V need not have one.
+type Map[V any] interface {
+	json.Marshaler
+	json.Unmarshaler
+
+	// Put stores value under key, returning false if key was already present
+	// and its value has been replaced
+	Put(key string, value V) bool
+	// Get returns the value stored under key
+	Get(key string) (V, bool)
+	// Delete removes key, returning false if it was not present
+	Delete(key string) bool
+	// Count returns the number of keys stored
+	Count() int
+
+	// All yields every key/value pair in lexicographic key order
+	All() iter.Seq2[string, V]
+	// WithPrefix yields every key/value pair whose key starts with prefix
+	// in lexicographic key order
+	WithPrefix(prefix string) iter.Seq2[string, V]
+}
+
+// trieMap stores the values in the trie nodes themselves
+type trieMap[V any] struct {
+	root *node[V]
+}
+
+func (m *trieMap[V]) Put(key string, value V) bool {
+	return m.root.put(key, value)
+}
+
+func (m *trieMap[V]) Get(key string) (V, bool) {
+	if n := m.root.get(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *trieMap[V]) Delete(key string) bool {
+	return m.root.delete(key)
+}
+
+func (m *trieMap[V]) Count() int {
+	return m.root.size
+}
+
+func (m *trieMap[V]) All() iter.Seq2[string, V] {
+	return m.root.withPrefix("")
+}
+
+func (m *trieMap[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return m.root.withPrefix(prefix)
+}
+
+// NewMap constructs an empty trie backed map
+func NewMap[V any]() Map[V] {
+	return &trieMap[V]{newNode[V]()}
+}
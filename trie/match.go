@@ -0,0 +1,89 @@
+package trie
+
+import "slices"
+
+// glob matches words against a pattern where '?' matches any single rune
+// and '*' matches any run of runes, including the empty one.  It is run as
+// an NFA whose states are positions in the pattern.
+type glob []rune
+
+// closure marks every state reachable by letting a '*' match nothing
+func (g glob) closure(states []bool) []bool {
+	for i, r := range g {
+		if states[i] && r == '*' {
+			states[i+1] = true
+		}
+	}
+	return states
+}
+
+func (g glob) start() []bool {
+	states := make([]bool, len(g)+1)
+	states[0] = true
+	return g.closure(states)
+}
+
+// step returns the states reached after reading r, and whether any are left
+func (g glob) step(states []bool, r rune) ([]bool, bool) {
+	next := make([]bool, len(states))
+	alive := false
+	for i, p := range g {
+		if !states[i] {
+			continue
+		}
+		switch p {
+		case '*':
+			next[i] = true
+		case '?', r:
+			next[i+1] = true
+		default:
+			continue
+		}
+		alive = true
+	}
+	return g.closure(next), alive
+}
+
+func (g glob) accepts(states []bool) bool {
+	return states[len(g)]
+}
+
+func (n *node[V]) match(buf []byte, g glob, states []bool, result []string) []string {
+	if n.set && g.accepts(states) {
+		result = append(result, string(buf))
+	}
+	for _, r := range n.keys() {
+		if next, alive := g.step(states, r); alive {
+			result = n.children[r].match(appendKey(buf, r), g, next, result)
+		}
+	}
+	return result
+}
+
+func (t *trie) Match(pattern string) []string {
+	g := glob(slices.Collect(keyRunes(pattern)))
+	return t.root.match(nil, g, g.start(), nil)
+}
+
+func (t *radix) match(buf []byte, g glob, states []bool, result []string) []string {
+	if t.word && g.accepts(states) {
+		result = append(result, string(buf))
+	}
+	for _, c := range t.children {
+		next, alive := states, true
+		for r := range keyRunes(c.label) {
+			if next, alive = g.step(next, r); !alive {
+				break
+			}
+		}
+		if alive {
+			result = c.match(append(buf, c.label...), g, next, result)
+		}
+	}
+	return result
+}
+
+func (t *radix) Match(pattern string) []string {
+	g := glob(slices.Collect(keyRunes(pattern)))
+	return t.match(nil, g, g.start(), nil)
+}
@@ -0,0 +1,162 @@
+package trie
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+	"unicode/utf8"
+)
+
+// maxKey is the largest rune decodeKey returns
+const maxKey = utf8.MaxRune + 1 + 0xff
+
+// decodeKey returns the first rune of s and its width in bytes.  Invalid
+// bytes are mapped past utf8.MaxRune so they stay distinct from each other
+// and from U+FFFD, and appendKey gives back the original byte.
+func decodeKey(s string) (rune, int) {
+	r, width := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && width == 1 {
+		return utf8.MaxRune + 1 + rune(s[0]), 1
+	}
+	return r, width
+}
+
+// appendKey appends the bytes r was decoded from by decodeKey
+func appendKey(buf []byte, r rune) []byte {
+	if r > utf8.MaxRune {
+		return append(buf, byte(r-utf8.MaxRune-1))
+	}
+	return utf8.AppendRune(buf, r)
+}
+
+// compareKeys orders two runes from decodeKey by the bytes they stand for,
+// so an invalid byte sorts among the valid runes by its value
+func compareKeys(a, b rune) int {
+	var x, y [utf8.UTFMax]byte
+	return bytes.Compare(appendKey(x[:0], a), appendKey(y[:0], b))
+}
+
+// keyRunes yields the runes of s as decoded by decodeKey
+func keyRunes(s string) iter.Seq[rune] {
+	return func(yield func(rune) bool) {
+		for len(s) > 0 {
+			r, width := decodeKey(s)
+			if !yield(r) {
+				return
+			}
+			s = s[width:]
+		}
+	}
+}
+
+// node is a trie node keyed by rune, shared by the plain trie (with an
+// empty value) and Map.  size counts the keys stored at or below the node.
+type node[V any] struct {
+	value    V
+	set      bool
+	size     int
+	children map[rune]*node[V]
+}
+
+func newNode[V any]() *node[V] {
+	return &node[V]{children: make(map[rune]*node[V])}
+}
+
+// find returns the node reached by following key, or nil
+func (n *node[V]) find(key string) *node[V] {
+	curr := n
+	for r := range keyRunes(key) {
+		curr = curr.children[r]
+		if curr == nil {
+			return nil
+		}
+	}
+	return curr
+}
+
+// get returns the node storing key, or nil
+func (n *node[V]) get(key string) *node[V] {
+	if found := n.find(key); found != nil && found.set {
+		return found
+	}
+	return nil
+}
+
+// put stores value under key, returning false if key was already present
+// and its value has been replaced
+func (n *node[V]) put(key string, value V) bool {
+	if found := n.get(key); found != nil {
+		found.value = value
+		return false
+	}
+
+	curr := n
+	curr.size++
+	for r := range keyRunes(key) {
+		next := curr.children[r]
+		if next == nil {
+			next = newNode[V]()
+			curr.children[r] = next
+		}
+		next.size++
+		curr = next
+	}
+	curr.value, curr.set = value, true
+	return true
+}
+
+// delete removes key, returning false if it was not present
+func (n *node[V]) delete(key string) bool {
+	if n.get(key) == nil {
+		return false
+	}
+
+	curr := n
+	curr.size--
+	for r := range keyRunes(key) {
+		next := curr.children[r]
+		next.size--
+		if next.size == 0 {
+			// nothing else lives below here, drop the whole branch
+			delete(curr.children, r)
+			return true
+		}
+		curr = next
+	}
+	var zero V
+	curr.value, curr.set = zero, false
+	return true
+}
+
+// keys returns the child runes ordered by compareKeys
+func (n *node[V]) keys() []rune {
+	result := make([]rune, 0, len(n.children))
+	for r := range n.children {
+		result = append(result, r)
+	}
+	slices.SortFunc(result, compareKeys)
+	return result
+}
+
+// walk visits every key below n in lexicographic order, buf holds the
+// path leading to n.  Returns false if yield asked to stop.
+func (n *node[V]) walk(buf []byte, yield func(string, V) bool) bool {
+	if n.set && !yield(string(buf), n.value) {
+		return false
+	}
+	for _, r := range n.keys() {
+		if !n.children[r].walk(appendKey(buf, r), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// withPrefix yields every key/value pair below the node reached by prefix
+func (n *node[V]) withPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if found := n.find(prefix); found != nil {
+			found.walk([]byte(prefix), yield)
+		}
+	}
+}
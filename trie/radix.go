@@ -0,0 +1,219 @@
+package trie
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// radix is a compressed trie where chains of single children are collapsed
+// into one edge label.  Every node other than the root has a non-empty label
+// and the children of a node are kept sorted by the leading rune of their
+// labels under compareKeys, no two children share a leading rune.
+type radix struct {
+	label    string
+	word     bool
+	size     int
+	children []*radix
+}
+
+// leading returns the first rune of s as decoded by decodeKey
+func leading(s string) rune {
+	r, _ := decodeKey(s)
+	return r
+}
+
+// commonPrefix returns the length in bytes of the longest common prefix
+// of a and b which never splits a rune
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) {
+		_, width := decodeKey(a[n:])
+		if !strings.HasPrefix(b[n:], a[n:n+width]) {
+			break
+		}
+		n += width
+	}
+	return n
+}
+
+// child returns the position of the child whose label starts with the
+// same rune as str, or where it would be inserted
+func (t *radix) child(str string) (int, bool) {
+	return slices.BinarySearchFunc(t.children, leading(str), func(c *radix, r rune) int {
+		return compareKeys(leading(c.label), r)
+	})
+}
+
+// merge folds t into its only child if t no longer holds a word
+func (t *radix) merge() {
+	if t.label == "" || t.word || len(t.children) != 1 {
+		return
+	}
+	c := t.children[0]
+	t.label += c.label
+	t.word = c.word
+	t.children = c.children
+}
+
+// find returns the node whose path is exactly str, or nil
+func (t *radix) find(str string) *radix {
+	curr := t
+	for str != "" {
+		i, ok := curr.child(str)
+		if !ok || !strings.HasPrefix(str, curr.children[i].label) {
+			return nil
+		}
+		curr = curr.children[i]
+		str = str[len(curr.label):]
+	}
+	return curr
+}
+
+// locate returns the highest node whose path starts with prefix together
+// with that path, or nil if no such node exists
+func (t *radix) locate(prefix string) (*radix, string) {
+	curr, path := t, ""
+	for rest := prefix; rest != ""; {
+		i, ok := curr.child(rest)
+		if !ok {
+			return nil, ""
+		}
+		next := curr.children[i]
+		if len(rest) < len(next.label) {
+			if commonPrefix(next.label, rest) != len(rest) {
+				return nil, ""
+			}
+			return next, path + next.label
+		}
+		if !strings.HasPrefix(rest, next.label) {
+			return nil, ""
+		}
+		curr, path, rest = next, path+next.label, rest[len(next.label):]
+	}
+	return curr, path
+}
+
+func (t *radix) Insert(str string) bool {
+	if t.Contains(str) {
+		return false
+	}
+
+	curr := t
+	curr.size++
+	for str != "" {
+		i, ok := curr.child(str)
+		if !ok {
+			leaf := &radix{label: str, word: true, size: 1}
+			curr.children = slices.Insert(curr.children, i, leaf)
+			return true
+		}
+
+		next := curr.children[i]
+		n := commonPrefix(next.label, str)
+		if n < len(next.label) {
+			// split the edge so the shared part becomes its own node
+			mid := &radix{label: next.label[:n], size: next.size, children: []*radix{next}}
+			next.label = next.label[n:]
+			curr.children[i] = mid
+			next = mid
+		}
+		next.size++
+		curr, str = next, str[n:]
+	}
+	curr.word = true
+	return true
+}
+
+func (t *radix) Remove(str string) bool {
+	if !t.Contains(str) {
+		return false
+	}
+
+	curr := t
+	curr.size--
+	for str != "" {
+		i, _ := curr.child(str)
+		next := curr.children[i]
+		if next.size == 1 {
+			// nothing else lives below here, drop the whole branch
+			curr.children = slices.Delete(curr.children, i, i+1)
+			curr.merge()
+			return true
+		}
+		next.size--
+		curr, str = next, str[len(next.label):]
+	}
+	curr.word = false
+	curr.merge()
+	return true
+}
+
+func (t *radix) Contains(str string) bool {
+	n := t.find(str)
+	return n != nil && n.word
+}
+
+func (t *radix) Count() int {
+	return t.size
+}
+
+// walk visits every word below t in lexicographic order, buf holds the
+// path leading to t.  Returns false if yield asked to stop.
+func (t *radix) walk(buf []byte, yield func(string) bool) bool {
+	if t.word && !yield(string(buf)) {
+		return false
+	}
+	for _, c := range t.children {
+		if !c.walk(append(buf, c.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *radix) HasPrefix(prefix string) bool {
+	n, _ := t.locate(prefix)
+	return n != nil && n.size > 0
+}
+
+func (t *radix) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if n, path := t.locate(prefix); n != nil {
+			n.walk([]byte(path), yield)
+		}
+	}
+}
+
+func (t *radix) WordsWithPrefix(prefix string, limit int) []string {
+	var result []string
+	for w := range t.WithPrefix(prefix) {
+		result = append(result, w)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result
+}
+
+func (t *radix) LongestPrefixOf(str string) (string, bool) {
+	curr := t
+	end, found := 0, t.word
+	for pos := 0; pos < len(str); {
+		i, ok := curr.child(str[pos:])
+		if !ok || !strings.HasPrefix(str[pos:], curr.children[i].label) {
+			break
+		}
+		curr = curr.children[i]
+		pos += len(curr.label)
+		if curr.word {
+			end, found = pos, true
+		}
+	}
+	return str[:end], found
+}
+
+// NewRadix constructs an empty compressed trie
+func NewRadix() Trie {
+	return &radix{}
+}
@@ -0,0 +1,142 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomWords generates n words over a small alphabet so they share
+// plenty of prefixes
+func randomWords(n int, seed int64) []string {
+	alphabet := []rune("abcé/")
+	r := rand.New(rand.NewSource(seed))
+	result := make([]string, n)
+	for i := range result {
+		w := make([]rune, r.Intn(8))
+		for j := range w {
+			w[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		result[i] = string(w)
+	}
+	return result
+}
+
+// urlPaths generates n distinct URL style paths
+func urlPaths(n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = fmt.Sprintf("/api/v%d/users/%d/items/%d", i%3, i/100, i)
+	}
+	return result
+}
+
+func TestRadixMatchesTrie(t *testing.T) {
+	expected, found := New(), NewRadix()
+
+	check := func(op string, w string, e, f interface{}) {
+		if e != f {
+			t.Fatalf("%s(%q): Expected %v Found: %v", op, w, e, f)
+		}
+	}
+
+	words := randomWords(2000, 1)
+	for i, w := range words {
+		if i%3 == 2 {
+			check("Remove", w, expected.Remove(w), found.Remove(w))
+		} else {
+			check("Insert", w, expected.Insert(w), found.Insert(w))
+		}
+		check("Count", w, expected.Count(), found.Count())
+	}
+
+	for _, w := range randomWords(500, 2) {
+		check("Contains", w, expected.Contains(w), found.Contains(w))
+		check("HasPrefix", w, expected.HasPrefix(w), found.HasPrefix(w))
+
+		e, eOk := expected.LongestPrefixOf(w)
+		f, fOk := found.LongestPrefixOf(w)
+		check("LongestPrefixOf", w, fmt.Sprint(e, eOk), fmt.Sprint(f, fOk))
+
+		if e, f := expected.WordsWithPrefix(w, 0), found.WordsWithPrefix(w, 0); !slices.Equal(e, f) {
+			t.Fatalf("WordsWithPrefix(%q): Expected %v Found: %v", w, e, f)
+		}
+		if e, f := expected.Fuzzy(w, 2), found.Fuzzy(w, 2); !slices.Equal(e, f) {
+			t.Fatalf("Fuzzy(%q): Expected %v Found: %v", w, e, f)
+		}
+		if p := "*" + w + "?"; !slices.Equal(expected.Match(p), found.Match(p)) {
+			t.Fatalf("Match(%q): Expected %v Found: %v", p, expected.Match(p), found.Match(p))
+		}
+	}
+
+	for _, w := range words {
+		expected.Remove(w)
+		found.Remove(w)
+	}
+	if root := found.(*radix); found.Count() != 0 || len(root.children) != 0 {
+		t.Errorf("Expected empty radix trie but found %d words and %d children", found.Count(), len(root.children))
+	}
+}
+
+func TestRadixCompresses(t *testing.T) {
+	tr := NewRadix()
+	tr.Insert("/api/users")
+	tr.Insert("/api/items")
+
+	root := tr.(*radix)
+	if len(root.children) != 1 || root.children[0].label != "/api/" {
+		t.Fatalf("Expected a single \"/api/\" edge but found %v", root.children)
+	}
+
+	tr.Remove("/api/items")
+	if len(root.children) != 1 || root.children[0].label != "/api/users" {
+		t.Fatalf("Expected the edges to merge back into \"/api/users\"")
+	}
+}
+
+func TestRadixSplitsOnRunes(t *testing.T) {
+	tr := NewRadix()
+	tr.Insert("é")
+	tr.Insert("è")
+
+	if tr.HasPrefix("\xc3") {
+		t.Error("A prefix ending inside a rune should not match")
+	}
+	if !tr.Contains("é") || !tr.Contains("è") {
+		t.Error("Expected both words to be stored")
+	}
+	if found := tr.WordsWithPrefix("", 0); !slices.Equal(found, []string{"è", "é"}) {
+		t.Errorf("Expected [è é] but found %v", found)
+	}
+}
+
+func benchmarkInsert(b *testing.B, newTrie func() Trie) {
+	words := urlPaths(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr := newTrie()
+		for _, w := range words {
+			tr.Insert(w)
+		}
+	}
+}
+
+func benchmarkContains(b *testing.B, newTrie func() Trie) {
+	words := urlPaths(10000)
+	tr := newTrie()
+	for _, w := range words {
+		tr.Insert(w)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.Contains(words[i%len(words)])
+	}
+}
+
+func BenchmarkTrieInsert(b *testing.B)    { benchmarkInsert(b, New) }
+func BenchmarkRadixInsert(b *testing.B)   { benchmarkInsert(b, NewRadix) }
+func BenchmarkTrieContains(b *testing.B)  { benchmarkContains(b, New) }
+func BenchmarkRadixContains(b *testing.B) { benchmarkContains(b, NewRadix) }
@@ -1,31 +1,120 @@
-
 package trie
 
-// Trie exports the common interface all tries have
+import (
+	"encoding"
+	"encoding/json"
+	"iter"
+)
+
+// Trie exports the common interface all tries have.
+//
+// Words are ordered rune by rune, comparing the UTF-8 bytes of each rune,
+// which is byte order for valid UTF-8.  A byte which is not valid UTF-8
+// counts as a rune of its own, compared as that one byte.  This only
+// differs from byte order when such a byte could start a longer rune, so
+// "\xc3\xff" sorts before "é", which is "\xc3\xa9", though not byte by byte.
+//
+// JSON cannot hold invalid UTF-8, so MarshalJSON fails with ErrInvalidUTF8
+// for such words rather than replace their bytes.  The binary encoding
+// keeps every word as it is.
 type Trie interface {
-	Insert(string)
-	Remove(string)
-	Contains(string)
-	Count()
+	json.Marshaler
+	json.Unmarshaler
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+
+	// Insert adds the word, returning false if it was already present
+	Insert(string) bool
+	// Remove deletes the word, returning false if it was not present
+	Remove(string) bool
+	// Contains reports whether the word is stored
+	Contains(string) bool
+	// Count returns the number of words stored
+	Count() int
+
+	// HasPrefix reports whether any stored word starts with prefix
+	HasPrefix(prefix string) bool
+	// WithPrefix yields every word starting with prefix in lexicographic order
+	WithPrefix(prefix string) iter.Seq[string]
+	// WordsWithPrefix returns at most limit words starting with prefix in
+	// lexicographic order.  A limit <= 0 returns all of them.
+	WordsWithPrefix(prefix string, limit int) []string
+	// LongestPrefixOf returns the longest stored word that is a prefix of str
+	LongestPrefixOf(str string) (string, bool)
+
+	// Fuzzy returns every word within Levenshtein distance k of query in
+	// lexicographic order
+	Fuzzy(query string, k int) []string
+	// Match returns every word matching pattern in lexicographic order, where
+	// '?' matches any single rune and '*' matches any run of runes
+	Match(pattern string) []string
 }
 
+// trie is a plain trie with one node per rune
 type trie struct {
-	word bool
-	children std::map[rune]*trie
-}
-
-func (t *trie) Insert(str string) {
-	runes := []rune(str)
-	
-	curr := t;
-	
-	for r : runes {
-		next := t.children[r]
-		if next == nil {
-			next = t.children[r] = &trie{word:false}
+	root *node[struct{}]
+}
+
+func (t *trie) Insert(str string) bool {
+	return t.root.put(str, struct{}{})
+}
+
+func (t *trie) Remove(str string) bool {
+	return t.root.delete(str)
+}
+
+func (t *trie) Contains(str string) bool {
+	return t.root.get(str) != nil
+}
+
+func (t *trie) Count() int {
+	return t.root.size
+}
+
+func (t *trie) HasPrefix(prefix string) bool {
+	n := t.root.find(prefix)
+	return n != nil && n.size > 0
+}
+
+func (t *trie) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for w := range t.root.withPrefix(prefix) {
+			if !yield(w) {
+				return
+			}
+		}
+	}
+}
+
+func (t *trie) WordsWithPrefix(prefix string, limit int) []string {
+	var result []string
+	for w := range t.WithPrefix(prefix) {
+		result = append(result, w)
+		if len(result) == limit {
+			break
 		}
-		curr = next
 	}
-	curr.word = true
+	return result
 }
 
+func (t *trie) LongestPrefixOf(str string) (string, bool) {
+	curr := t.root
+	end, found := 0, curr.set
+	for i := 0; i < len(str); {
+		r, width := decodeKey(str[i:])
+		i += width
+		curr = curr.children[r]
+		if curr == nil {
+			break
+		}
+		if curr.set {
+			end, found = i, true
+		}
+	}
+	return str[:end], found
+}
+
+// New constructs an empty trie
+func New() Trie {
+	return &trie{newNode[struct{}]()}
+}
@@ -0,0 +1,357 @@
+package trie
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var words = []string{"", "a", "an", "and", "ant", "bee", "héllo", "hello"}
+
+func TestTrieNew(t *testing.T) {
+	tr := New()
+	if tr.Count() != 0 {
+		t.Error("Expected 0 size Trie but found ", tr.Count())
+	}
+	if tr.Contains("a") {
+		t.Error("Empty trie should not contain anything")
+	}
+}
+
+func TestTrieInsert(t *testing.T) {
+	tr := New()
+	for _, w := range words {
+		if !tr.Insert(w) {
+			t.Errorf("Expected Insert(%q) to add a new word", w)
+		}
+	}
+	for _, w := range words {
+		if tr.Insert(w) {
+			t.Errorf("Expected Insert(%q) to report a duplicate", w)
+		}
+	}
+	if tr.Count() != len(words) {
+		t.Errorf("Expected %d words but found %d", len(words), tr.Count())
+	}
+	for _, w := range words {
+		if !tr.Contains(w) {
+			t.Errorf("Expected trie to contain %q", w)
+		}
+	}
+	for _, w := range []string{"b", "anda", "h", "hé"} {
+		if tr.Contains(w) {
+			t.Errorf("Did not expect trie to contain %q", w)
+		}
+	}
+}
+
+func TestTrieRemove(t *testing.T) {
+	tr := New()
+	for _, w := range words {
+		tr.Insert(w)
+	}
+	if tr.Remove("b") || tr.Remove("andy") {
+		t.Error("Removing a missing word should return false")
+	}
+	for i, w := range words {
+		if !tr.Remove(w) {
+			t.Errorf("Expected Remove(%q) to succeed", w)
+		}
+		if tr.Contains(w) {
+			t.Errorf("Expected %q to be removed", w)
+		}
+		if tr.Count() != len(words)-i-1 {
+			t.Errorf("Expected %d words but found %d", len(words)-i-1, tr.Count())
+		}
+		for _, rest := range words[i+1:] {
+			if !tr.Contains(rest) {
+				t.Errorf("Removing %q also removed %q", w, rest)
+			}
+		}
+	}
+}
+
+func TestTriePrunes(t *testing.T) {
+	tr := New()
+	tr.Insert("and")
+	tr.Insert("ant")
+	tr.Remove("and")
+
+	root := tr.(*trie).root
+	if n := root.find("an"); n == nil || len(n.children) != 1 {
+		t.Fatal("Expected the \"d\" branch to be pruned")
+	}
+	tr.Remove("ant")
+	if len(root.children) != 0 {
+		t.Errorf("Expected empty root but found %d children", len(root.children))
+	}
+}
+
+func TestTrieHasPrefix(t *testing.T) {
+	tr := New()
+	if tr.HasPrefix("") {
+		t.Error("Empty trie should not have any prefix")
+	}
+	tr.Insert("and")
+	for _, p := range []string{"", "a", "an", "and"} {
+		if !tr.HasPrefix(p) {
+			t.Errorf("Expected prefix %q to exist", p)
+		}
+	}
+	for _, p := range []string{"b", "andy", "n"} {
+		if tr.HasPrefix(p) {
+			t.Errorf("Did not expect prefix %q to exist", p)
+		}
+	}
+}
+
+func TestTrieWordsWithPrefix(t *testing.T) {
+	tr := New()
+	for i := len(words) - 1; i >= 0; i-- {
+		tr.Insert(words[i])
+	}
+
+	expected := []string{"a", "an", "and", "ant"}
+	found := tr.WordsWithPrefix("a", 0)
+	if !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+
+	found = tr.WordsWithPrefix("a", 2)
+	if !slices.Equal(found, expected[:2]) {
+		t.Errorf("Expected %v but found %v", expected[:2], found)
+	}
+
+	expected = []string{"", "a", "an", "and", "ant", "bee", "hello", "héllo"}
+	found = slices.Collect(tr.WithPrefix(""))
+	if !slices.Equal(found, expected) {
+		t.Errorf("Expected %v but found %v", expected, found)
+	}
+
+	if found := tr.WordsWithPrefix("x", 0); len(found) != 0 {
+		t.Errorf("Expected no words but found %v", found)
+	}
+}
+
+func TestTrieLongestPrefixOf(t *testing.T) {
+	tr := New()
+	for _, w := range []string{"/", "/api", "/api/v1", "/héllo"} {
+		tr.Insert(w)
+	}
+
+	tests := []struct {
+		in, out string
+		found   bool
+	}{
+		{"/api/v1/users", "/api/v1", true},
+		{"/api/v2", "/api", true},
+		{"/ap", "/", true},
+		{"/héllo/world", "/héllo", true},
+		{"api", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		out, found := tr.LongestPrefixOf(test.in)
+		if out != test.out || found != test.found {
+			t.Errorf("LongestPrefixOf(%q): Expected (%q,%v) Found: (%q,%v)", test.in, test.out, test.found, out, found)
+		}
+	}
+
+	tr.Insert("")
+	if out, found := tr.LongestPrefixOf("api"); out != "" || !found {
+		t.Errorf("Expected the empty word to match but found (%q,%v)", out, found)
+	}
+}
+
+func TestMapPutGet(t *testing.T) {
+	m := NewMap[int]()
+	for i, w := range words {
+		if !m.Put(w, i) {
+			t.Errorf("Expected Put(%q) to add a new key", w)
+		}
+	}
+	if m.Count() != len(words) {
+		t.Errorf("Expected %d keys but found %d", len(words), m.Count())
+	}
+	if m.Put("and", 100) {
+		t.Error("Expected Put to replace an existing key")
+	}
+	if m.Count() != len(words) {
+		t.Errorf("Replacing a value changed the count to %d", m.Count())
+	}
+
+	for i, w := range words {
+		expected := i
+		if w == "and" {
+			expected = 100
+		}
+		if v, ok := m.Get(w); !ok || v != expected {
+			t.Errorf("Get(%q): Expected (%d,true) Found: (%d,%v)", w, expected, v, ok)
+		}
+	}
+	if v, ok := m.Get("anda"); ok || v != 0 {
+		t.Errorf("Get(\"anda\"): Expected (0,false) Found: (%d,%v)", v, ok)
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	m := NewMap[string]()
+	for _, w := range words {
+		m.Put(w, w)
+	}
+	if m.Delete("b") {
+		t.Error("Deleting a missing key should return false")
+	}
+	for i, w := range words {
+		if !m.Delete(w) {
+			t.Errorf("Expected Delete(%q) to succeed", w)
+		}
+		if _, ok := m.Get(w); ok {
+			t.Errorf("Expected %q to be deleted", w)
+		}
+		if m.Count() != len(words)-i-1 {
+			t.Errorf("Expected %d keys but found %d", len(words)-i-1, m.Count())
+		}
+	}
+	if root := m.(*trieMap[string]).root; len(root.children) != 0 {
+		t.Errorf("Expected empty root but found %d children", len(root.children))
+	}
+}
+
+func TestMapIteration(t *testing.T) {
+	m := NewMap[int]()
+	for i := len(words) - 1; i >= 0; i-- {
+		m.Put(words[i], len(words[i]))
+	}
+
+	var keys []string
+	for k, v := range m.All() {
+		if v != len(k) {
+			t.Errorf("Key %q has value %d", k, v)
+		}
+		keys = append(keys, k)
+	}
+	expected := []string{"", "a", "an", "and", "ant", "bee", "hello", "héllo"}
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Expected %v but found %v", expected, keys)
+	}
+
+	keys = keys[:0]
+	for k := range m.WithPrefix("an") {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, expected[2:5]) {
+		t.Errorf("Expected %v but found %v", expected[2:5], keys)
+	}
+}
+
+func TestTrieFuzzy(t *testing.T) {
+	tr := New()
+	for _, w := range []string{"book", "books", "boot", "cake", "cook", "héllo", "hello"} {
+		tr.Insert(w)
+	}
+
+	tests := []struct {
+		query    string
+		k        int
+		expected []string
+	}{
+		{"book", 0, []string{"book"}},
+		{"book", 1, []string{"book", "books", "boot", "cook"}},
+		{"bxxk", 2, []string{"book"}},
+		{"hallo", 1, []string{"hello", "héllo"}},
+		{"", 4, []string{"book", "boot", "cake", "cook"}},
+		{"book", -1, nil},
+	}
+	for _, test := range tests {
+		found := tr.Fuzzy(test.query, test.k)
+		if !slices.Equal(found, test.expected) {
+			t.Errorf("Fuzzy(%q,%d): Expected %v Found: %v", test.query, test.k, test.expected, found)
+		}
+	}
+}
+
+func TestTrieMatch(t *testing.T) {
+	tr := New()
+	for _, w := range []string{"", "db1.example.com", "db2.example.com", "web1.example.com", "web1.example.org", "hé"} {
+		tr.Insert(w)
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"db?.example.com", []string{"db1.example.com", "db2.example.com"}},
+		{"*.com", []string{"db1.example.com", "db2.example.com", "web1.example.com"}},
+		{"web1.*", []string{"web1.example.com", "web1.example.org"}},
+		{"*1*", []string{"db1.example.com", "web1.example.com", "web1.example.org"}},
+		{"h?", []string{"hé"}},
+		{"", []string{""}},
+		{"**", []string{"", "db1.example.com", "db2.example.com", "hé", "web1.example.com", "web1.example.org"}},
+		{"db?", nil},
+	}
+	for _, test := range tests {
+		found := tr.Match(test.pattern)
+		if !slices.Equal(found, test.expected) {
+			t.Errorf("Match(%q): Expected %v Found: %v", test.pattern, test.expected, found)
+		}
+	}
+}
+
+func TestTrieInvalidUTF8(t *testing.T) {
+	for name, newTrie := range map[string]func() Trie{"trie": New, "radix": NewRadix} {
+		tr := newTrie()
+		for _, w := range []string{"\xff", "a\xc3", "é", "\x80"} {
+			tr.Insert(w)
+		}
+
+		for _, w := range []string{"\xfe", "�", "a�", "\xc3"} {
+			if tr.Contains(w) {
+				t.Errorf("%s: Did not expect %q to be stored", name, w)
+			}
+		}
+		if !tr.Contains("\xff") || !tr.Contains("a\xc3") {
+			t.Errorf("%s: Expected the invalid words to be stored as given", name)
+		}
+
+		// invalid bytes sort by value, matching byte order here
+		expected := []string{"a\xc3", "\x80", "é", "\xff"}
+		if found := tr.WordsWithPrefix("", 0); !slices.Equal(found, expected) || !slices.IsSorted(found) {
+			t.Errorf("%s: Expected %q Found: %q", name, expected, found)
+		}
+		if found := tr.Match("a?"); !slices.Equal(found, expected[:1]) {
+			t.Errorf("%s: Match: Expected %q Found: %q", name, expected[:1], found)
+		}
+		if found := tr.Fuzzy("\xfe", 0); len(found) != 0 {
+			t.Errorf("%s: Fuzzy: Expected no words but found %q", name, found)
+		}
+		if out, found := tr.LongestPrefixOf("\xff\xff"); out != "\xff" || !found {
+			t.Errorf("%s: LongestPrefixOf: Expected (%q,true) Found: (%q,%v)", name, "\xff", out, found)
+		}
+
+		bin, err := tr.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := newTrie()
+		if err := c.UnmarshalBinary(bin); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if found := c.WordsWithPrefix("", 0); !slices.Equal(found, expected) {
+			t.Errorf("%s: Expected %q after decoding Found: %q", name, expected, found)
+		}
+
+		// JSON would turn every invalid byte into U+FFFD
+		if _, err := json.Marshal(tr); !errors.Is(err, ErrInvalidUTF8) {
+			t.Errorf("%s: Expected ErrInvalidUTF8 encoding JSON but found %v", name, err)
+		}
+	}
+
+	// a rune decodeKey never produces, here 'a' disguised as an invalid byte
+	data := binary.AppendUvarint([]byte{trieFormat, encodingVersion, 0, 1}, uint64(maxKey-0xff+'a'))
+	if err := New().UnmarshalBinary(append(data, wordFlag, 0)); err == nil {
+		t.Error("Expected an error decoding an impossible rune")
+	}
+}